@@ -27,6 +27,7 @@ import argparse
 import functools
 import json
 import os
+import re
 import secrets
 import subprocess
 import sys
@@ -67,6 +68,7 @@ try:
         BLOCKED_GH_COMMANDS,
         READONLY_GH_COMMANDS,
         get_github_client,
+        is_gh_write_command,
         validate_gh_api_path,
     )
     from .policy import (
@@ -78,9 +80,14 @@ try:
         check_private_repo_access,
     )
     from .rate_limiter import (
+        check_branch_push_cooldown,
         check_heartbeat_rate_limit,
         check_registration_rate_limit,
+        check_repo_write_rate_limit,
+        record_branch_push,
         record_failed_lookup,
+        record_write_failure,
+        reset_write_failures,
     )
     from .repo_parser import parse_owner_repo
     from .repo_visibility import get_repo_visibility
@@ -108,6 +115,7 @@ except ImportError:
         READONLY_GH_COMMANDS,
         extract_repo_from_gh_command,
         get_github_client,
+        is_gh_write_command,
         parse_gh_api_args,
         validate_gh_api_path,
     )
@@ -120,9 +128,14 @@ except ImportError:
         check_private_repo_access,
     )
     from rate_limiter import (
+        check_branch_push_cooldown,
         check_heartbeat_rate_limit,
         check_registration_rate_limit,
+        check_repo_write_rate_limit,
+        record_branch_push,
         record_failed_lookup,
+        record_write_failure,
+        reset_write_failures,
     )
     from repo_parser import parse_owner_repo
     from repo_visibility import get_repo_visibility
@@ -222,6 +235,21 @@ def require_session_auth(f):
         g.session = result.session
         g.session_mode = result.session.mode if result.session else None
 
+        # Quarantined sessions stay read-only until an operator restores writes
+        if result.session and result.session.quarantined and is_write_request():
+            audit_log(
+                "write_blocked_quarantined",
+                request.endpoint,
+                success=False,
+                details={"container_id": result.session.container_id},
+            )
+            return make_error(
+                "Writes are suspended for this session after repeated failures. "
+                "An operator must restore writes before pushing or editing PRs again.",
+                status_code=403,
+                details={"quarantined": True},
+            )
+
         return f(*args, **kwargs)
 
     return decorated
@@ -352,6 +380,141 @@ def audit_log(
         logger.warning(f"Audit: {event_type}", **log_data)
 
 
+def parse_timestamp(value: Any) -> datetime | None:
+    """Parse an ISO 8601 timestamp ("Z" or offset form); naive values are taken as UTC."""
+    if not isinstance(value, str):
+        return None
+    try:
+        parsed = datetime.fromisoformat(value)
+    except ValueError:
+        return None
+    return parsed if parsed.tzinfo else parsed.replace(tzinfo=UTC)
+
+
+# Endpoints that mutate GitHub state; subject to write limits and quarantine
+WRITE_ENDPOINTS = frozenset(
+    {
+        "git_push",
+        "gh_pr_create",
+        "gh_pr_comment",
+        "gh_pr_edit",
+        "gh_pr_close",
+    }
+)
+
+# Failure output that points at GitHub or the network rather than the command
+TRANSIENT_FAILURE_PATTERN = re.compile(
+    r"HTTP 5\d\d|could not resolve host|error connecting|"
+    r"connection (timed out|reset|refused)",
+    re.IGNORECASE,
+)
+
+
+def is_write_request() -> bool:
+    """Check whether the current request may mutate GitHub state."""
+    if request.endpoint in WRITE_ENDPOINTS:
+        return True
+    if request.endpoint == "gh_execute":
+        data = request.get_json(silent=True) or {}
+        args = data.get("args") if isinstance(data, dict) else None
+        return isinstance(args, list) and is_gh_write_command(args)
+    return False
+
+
+def mark_write_command_failed(stderr: str | None) -> None:
+    """
+    Flag the current request as a write that git/gh itself rejected.
+
+    Transient failures (GitHub 5xx, network errors) are not the session's
+    fault and don't count towards quarantine.
+    """
+    if not TRANSIENT_FAILURE_PATTERN.search(stderr or ""):
+        g.write_command_failed = True
+
+
+def check_write_rate_limit(repo: str, operation: str, branch: str | None = None):
+    """
+    Enforce the per-repo write budget and, for pushes, the per-branch cooldown.
+
+    The cooldown is only checked here; git_push records the push once git is
+    actually invoked, so a push that fails earlier doesn't start it.
+
+    Returns an error response if the write should be rejected, otherwise None.
+    """
+    if branch:
+        cooldown = check_branch_push_cooldown(repo, branch)
+        if not cooldown.allowed:
+            audit_log(
+                "write_rate_limited",
+                operation,
+                success=False,
+                details={
+                    "repo": repo,
+                    "branch": branch,
+                    "limit": "branch_push_cooldown",
+                    "retry_after_seconds": cooldown.retry_after_seconds,
+                },
+            )
+            return make_error(
+                f"Branch '{branch}' was pushed too recently. "
+                f"Retry after {cooldown.retry_after_seconds}s",
+                status_code=429,
+                details=cooldown.to_dict(),
+            )
+
+    budget = check_repo_write_rate_limit(repo)
+    if not budget.allowed:
+        audit_log(
+            "write_rate_limited",
+            operation,
+            success=False,
+            details={
+                "repo": repo,
+                "branch": branch,
+                "limit": "repo_writes",
+                "retry_after_seconds": budget.retry_after_seconds,
+            },
+        )
+        return make_error(
+            f"Hourly write limit reached for {repo}. Retry after {budget.retry_after_seconds}s",
+            status_code=429,
+            details=budget.to_dict(),
+        )
+    return None
+
+
+@app.after_request
+def track_write_failures(response):
+    """
+    Quarantine a session whose writes keep failing or being denied.
+
+    A storm of failures usually means an agent is looping on something it
+    cannot fix; making the session read-only stops it doing further damage.
+    Only denials (403), conflicts and rate limits (409/429), and failures
+    git/gh report for the command itself count; gateway errors, timeouts and
+    GitHub outages don't.
+    """
+    session = getattr(g, "session", None)
+    if session is None or session.quarantined or not is_write_request():
+        return response
+    if response.status_code not in (403, 409, 429) and not getattr(
+        g, "write_command_failed", False
+    ):
+        return response
+
+    if not record_write_failure(session.container_id).allowed:
+        get_session_manager().set_quarantined(session.container_id, True)
+        logger.error(
+            "Session quarantined after repeated write failures",
+            event_type="session_quarantined",
+            container_id=session.container_id,
+            operation=request.endpoint,
+            status_code=response.status_code,
+            source_ip=request.remote_addr,
+        )
+    return response
+
+
 @app.route("/api/v1/health", methods=["GET"])
 def health_check():
     """Health check endpoint (no auth required)."""
@@ -525,6 +688,11 @@ def git_push():
             details=policy_result.details,
         )
 
+    # Per-branch push cooldown and per-repo write budget
+    limit_error = check_write_rate_limit(repo, "git_push", branch=branch)
+    if limit_error:
+        return limit_error
+
     # Get authentication token using shared helper
     token_str, auth_mode, token_error = get_token_for_repo(repo)
     if not token_str:
@@ -557,6 +725,7 @@ def git_push():
     try:
         credential_helper_path, env = create_credential_helper(token_str, os.environ.copy())
 
+        record_branch_push(repo, branch)
         result = subprocess.run(
             cmd,
             cwd=exec_path,
@@ -590,6 +759,7 @@ def git_push():
                 },
             )
         else:
+            mark_write_command_failed(result.stderr)
             audit_log(
                 "push_failed",
                 "git_push",
@@ -1108,6 +1278,10 @@ def gh_pr_create():
             details=policy_result.details,
         )
 
+    limit_error = check_write_rate_limit(repo, "gh_pr_create")
+    if limit_error:
+        return limit_error
+
     try:
         github = get_github_client(mode=auth_mode)
         args = [
@@ -1146,6 +1320,7 @@ def gh_pr_create():
             )
         else:
             error_msg = result.stderr or "Unknown error"
+            mark_write_command_failed(error_msg)
             audit_log(
                 "pr_create_failed",
                 "gh_pr_create",
@@ -1253,6 +1428,10 @@ def gh_pr_comment():
             details=policy_result.details,
         )
 
+    limit_error = check_write_rate_limit(repo, "gh_pr_comment")
+    if limit_error:
+        return limit_error
+
     github = get_github_client(mode=auth_mode)
     args = [
         "pr",
@@ -1275,6 +1454,7 @@ def gh_pr_comment():
         )
         return make_success("Comment added", {"stdout": result.stdout, "auth_mode": auth_mode})
     else:
+        mark_write_command_failed(result.stderr)
         return make_error(
             f"Failed to add comment: {result.stderr}",
             status_code=500,
@@ -1293,10 +1473,14 @@ def gh_pr_edit():
             "repo": "owner/repo",
             "pr_number": 123,
             "title": "New title",  # optional
-            "body": "New body"      # optional
+            "body": "New body",     # optional
+            "expected_updated_at": "2024-01-01T00:00:00Z"  # optional
         }
 
     Policy: pr_ownership
+
+    If expected_updated_at is given, the edit is rejected with 409 when the
+    PR's current updatedAt differs, so concurrent human edits aren't clobbered.
     """
     data = request.get_json()
     if not data:
@@ -1306,6 +1490,7 @@ def gh_pr_edit():
     pr_number = data.get("pr_number")
     title = data.get("title")
     body = data.get("body")
+    expected_updated_at = data.get("expected_updated_at")
 
     if not repo:
         return make_error("Missing repo")
@@ -1313,6 +1498,9 @@ def gh_pr_edit():
         return make_error("Missing pr_number")
     if not title and not body:
         return make_error("Must provide title or body to edit")
+    expected_version = parse_timestamp(expected_updated_at) if expected_updated_at else None
+    if expected_updated_at and expected_version is None:
+        return make_error(f"Invalid expected_updated_at timestamp: {expected_updated_at}")
 
     # Determine auth mode for this repo
     auth_mode = get_auth_mode(repo)
@@ -1372,6 +1560,41 @@ def gh_pr_edit():
         )
 
     github = get_github_client(mode=auth_mode)
+
+    # Optimistic concurrency check - refuse the edit if the PR changed since it was read
+    if expected_version:
+        current_updated_at = github.get_pr_updated_at(repo, pr_number, mode=auth_mode)
+        current_version = parse_timestamp(current_updated_at)
+        if current_version is None:
+            return make_error(
+                f"Failed to verify current version of PR #{pr_number}", status_code=500
+            )
+        # Compare instants, not strings: "...Z" and "...+00:00" are the same version
+        if current_version != expected_version:
+            audit_log(
+                "pr_edit_conflict",
+                "gh_pr_edit",
+                success=False,
+                details={
+                    "repo": repo,
+                    "pr_number": pr_number,
+                    "expected_updated_at": expected_updated_at,
+                    "current_updated_at": current_updated_at,
+                },
+            )
+            return make_error(
+                f"PR #{pr_number} was modified since it was read",
+                status_code=409,
+                details={
+                    "expected_updated_at": expected_updated_at,
+                    "current_updated_at": current_updated_at,
+                },
+            )
+
+    limit_error = check_write_rate_limit(repo, "gh_pr_edit")
+    if limit_error:
+        return limit_error
+
     args = ["pr", "edit", str(pr_number), "--repo", repo]
     if title:
         args.extend(["--title", title])
@@ -1389,6 +1612,7 @@ def gh_pr_edit():
         )
         return make_success("PR edited", {"stdout": result.stdout, "auth_mode": auth_mode})
     else:
+        mark_write_command_failed(result.stderr)
         return make_error(
             f"Failed to edit PR: {result.stderr}",
             status_code=500,
@@ -1479,6 +1703,10 @@ def gh_pr_close():
             details=policy_result.details,
         )
 
+    limit_error = check_write_rate_limit(repo, "gh_pr_close")
+    if limit_error:
+        return limit_error
+
     github = get_github_client(mode=auth_mode)
     args = ["pr", "close", str(pr_number), "--repo", repo]
 
@@ -1493,6 +1721,7 @@ def gh_pr_close():
         )
         return make_success("PR closed", {"stdout": result.stdout, "auth_mode": auth_mode})
     else:
+        mark_write_command_failed(result.stderr)
         return make_error(
             f"Failed to close PR: {result.stderr}",
             status_code=500,
@@ -1528,6 +1757,9 @@ def gh_execute():
     if not args:
         return make_error("Missing args")
 
+    # Classify before --repo injection below changes the argument layout
+    is_write = is_gh_write_command(args)
+
     # Get session mode from request context (set by @require_session_auth decorator)
     session_mode = getattr(g, "session_mode", None)
 
@@ -1577,7 +1809,9 @@ def gh_execute():
                 success=False,
                 details={"command_args": args},
             )
-            return make_error("No API path provided in gh api command", status_code=400)
+            return make_error(
+                "Could not determine a single API path in gh api command", status_code=400
+            )
 
         path_valid, path_error = validate_gh_api_path(api_path, method)
         if not path_valid:
@@ -1633,6 +1867,12 @@ def gh_execute():
                     details=priv_result.to_dict(),
                 )
 
+    # Writes count against the per-repo budget like the dedicated write endpoints
+    if is_write and repo:
+        limit_error = check_write_rate_limit(repo, "gh_execute")
+        if limit_error:
+            return limit_error
+
     # Execute the command
     github = get_github_client(mode=auth_mode)
     result = github.execute(args, timeout=60, cwd=cwd, mode=auth_mode)
@@ -1642,6 +1882,8 @@ def gh_execute():
         response_data["auth_mode"] = auth_mode
         return make_success("Command executed", response_data)
     else:
+        if is_write:
+            mark_write_command_failed(result.stderr)
         return make_error(
             f"Command failed: {result.stderr}",
             status_code=500,
@@ -2253,6 +2495,40 @@ def session_heartbeat(session_token: str):
     )
 
 
+@app.route("/api/v1/sessions/restore-writes", methods=["POST"])
+@require_launcher_auth
+def session_restore_writes():
+    """
+    Lift quarantine from a session so it can write again.
+
+    Request body:
+        {
+            "container_id": "abc123"
+        }
+
+    Auth: Bearer {launcher_secret}
+    """
+    data = request.get_json()
+    if not data:
+        return make_error("Missing request body")
+
+    container_id = data.get("container_id")
+    if not container_id:
+        return make_error("Missing container_id")
+
+    if not get_session_manager().set_quarantined(container_id, False):
+        return make_error("Session not found", status_code=404)
+
+    reset_write_failures(container_id)
+    audit_log(
+        "session_writes_restored",
+        "session_restore_writes",
+        success=True,
+        details={"container_id": container_id},
+    )
+    return make_success("Session writes restored", {"container_id": container_id})
+
+
 @app.route("/api/v1/repos/visibility", methods=["GET"])
 @require_launcher_auth
 def repos_visibility():
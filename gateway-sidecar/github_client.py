@@ -10,15 +10,18 @@ Provides:
 Token management is handled by the in-memory token refresher (token_refresher.py).
 """
 
+import base64
 import json
 import os
 import re
 import subprocess
 import sys
+import tempfile
 from dataclasses import dataclass
 from datetime import UTC, datetime
 from pathlib import Path
 from typing import Any
+from urllib.parse import unquote
 
 
 # Add shared directory to path for jib_logging
@@ -45,6 +48,10 @@ GH_CLI = "/usr/bin/gh"
 # User token from environment variable (for user mode)
 USER_TOKEN_VAR = "GITHUB_USER_TOKEN"
 
+# Largest gh stdout returned through the gateway. Binary downloads are base64- and
+# JSON-encoded on the way out, so anything bigger is refused rather than buffered.
+GH_MAX_OUTPUT_BYTES = int(os.environ.get("GATEWAY_GH_MAX_OUTPUT_BYTES", 25 * 1024 * 1024))
+
 
 # =============================================================================
 # gh Command Validation
@@ -65,6 +72,16 @@ READONLY_GH_COMMANDS = frozenset(
         "repo list",
         "release view",
         "release list",
+        "run view",
+        "run list",
+        "workflow view",
+        "workflow list",
+        "search issues",
+        "search prs",
+        "search repos",
+        "search commits",
+        "search code",
+        "label list",
         "api",  # Read-only API calls (GET)
         "auth status",
         "config get",
@@ -107,7 +124,6 @@ GH_API_ALLOWED_PATHS = [
     re.compile(r"^repos/[^/]+/[^/]+/issues/\d+/events$"),  # Issue events
     re.compile(r"^repos/[^/]+/[^/]+/issues/\d+/timeline$"),  # Issue timeline
     # Repository info
-    re.compile(r"^repos/[^/]+/[^/]+$"),  # Repo info
     re.compile(r"^repos/[^/]+/[^/]+/branches$"),  # List branches
     re.compile(r"^repos/[^/]+/[^/]+/branches/[^/]+$"),  # Branch info
     re.compile(r"^repos/[^/]+/[^/]+/commits$"),  # List commits
@@ -122,11 +138,119 @@ GH_API_ALLOWED_PATHS = [
     re.compile(r"^repos/[^/]+/[^/]+/releases/\d+$"),  # Specific release
     re.compile(r"^repos/[^/]+/[^/]+/releases/latest$"),  # Latest release
     re.compile(r"^repos/[^/]+/[^/]+/releases/tags/[^/]+$"),  # Release by tag
+    re.compile(r"^repos/[^/]+/[^/]+/releases/generate-notes$"),  # Generate release notes
     # User info
     re.compile(r"^user$"),  # Current user
     re.compile(r"^users/[^/]+$"),  # User info
 ]
 
+# Allowlist of gh api paths that are permitted for GET only
+# These expose read-only data; POST/PATCH on them is rejected even though
+# the methods are allowed for GH_API_ALLOWED_PATHS
+GH_API_READONLY_PATHS = [
+    # Git objects (commit and tag objects include signature verification)
+    re.compile(r"^repos/[^/]+/[^/]+/git/commits/[a-f0-9]+$"),  # Commit object
+    re.compile(r"^repos/[^/]+/[^/]+/git/tags/[a-f0-9]+$"),  # Annotated tag object
+    re.compile(r"^repos/[^/]+/[^/]+/git/trees/[^/]+$"),  # Tree by SHA or ref (modes, types)
+    re.compile(r"^repos/[^/]+/[^/]+/git/blobs/[a-f0-9]+$"),  # Blob (files over 1MB)
+    # CODEOWNERS
+    re.compile(r"^repos/[^/]+/[^/]+/codeowners/errors$"),  # CODEOWNERS syntax errors
+    # Branch protection
+    re.compile(r"^repos/[^/]+/[^/]+/branches/[^/]+/protection$"),  # Branch protection
+    re.compile(r"^repos/[^/]+/[^/]+/branches/[^/]+/protection/[a-z_]+$"),  # Protection settings
+    # Repository rulesets
+    re.compile(r"^repos/[^/]+/[^/]+/rulesets$"),  # List rulesets
+    re.compile(r"^repos/[^/]+/[^/]+/rulesets/\d+$"),  # Specific ruleset
+    re.compile(r"^repos/[^/]+/[^/]+/rulesets/rule-suites$"),  # Rule evaluation results
+    re.compile(r"^repos/[^/]+/[^/]+/rulesets/rule-suites/\d+$"),  # Specific rule suite
+    re.compile(r"^repos/[^/]+/[^/]+/rules/branches/[^/]+$"),  # Rules applying to a branch
+    # Tags
+    re.compile(r"^repos/[^/]+/[^/]+/tags$"),  # List tags
+    # Release assets
+    re.compile(r"^repos/[^/]+/[^/]+/releases/\d+/assets$"),  # List release assets
+    re.compile(r"^repos/[^/]+/[^/]+/releases/assets/\d+$"),  # Release asset (metadata or download)
+    # GitHub Actions
+    re.compile(r"^repos/[^/]+/[^/]+/actions/workflows$"),  # List workflows
+    re.compile(r"^repos/[^/]+/[^/]+/actions/workflows/[^/]+$"),  # Workflow by ID or file name
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs$"),  # List workflow runs
+    re.compile(r"^repos/[^/]+/[^/]+/actions/workflows/[^/]+/runs$"),  # Runs for a workflow
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+$"),  # Specific workflow run
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/attempts/\d+$"),  # Specific run attempt
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/jobs$"),  # Jobs for a run
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/attempts/\d+/jobs$"),  # Jobs for a run attempt
+    re.compile(r"^repos/[^/]+/[^/]+/actions/jobs/\d+$"),  # Specific job (steps, runner labels)
+    re.compile(r"^repos/[^/]+/[^/]+/actions/jobs/\d+/logs$"),  # Job log (plain text)
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/logs$"),  # Run log archive
+    re.compile(r"^repos/[^/]+/[^/]+/actions/artifacts$"),  # List artifacts
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/artifacts$"),  # Artifacts for a run
+    re.compile(r"^repos/[^/]+/[^/]+/actions/artifacts/\d+$"),  # Specific artifact
+    re.compile(r"^repos/[^/]+/[^/]+/actions/artifacts/\d+/zip$"),  # Artifact download
+    re.compile(r"^repos/[^/]+/[^/]+/actions/caches$"),  # List caches
+    re.compile(r"^repos/[^/]+/[^/]+/actions/cache/usage$"),  # Cache usage
+    re.compile(r"^repos/[^/]+/[^/]+/actions/workflows/[^/]+/timing$"),  # Workflow billable time
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/timing$"),  # Run duration and billable time
+    # GitHub Pages
+    re.compile(r"^repos/[^/]+/[^/]+/pages$"),  # Pages configuration
+    re.compile(r"^repos/[^/]+/[^/]+/pages/builds$"),  # List Pages builds
+    re.compile(r"^repos/[^/]+/[^/]+/pages/builds/(latest|\d+)$"),  # Specific Pages build
+    # Deployment environments
+    re.compile(r"^repos/[^/]+/[^/]+/environments$"),  # List environments
+    re.compile(r"^repos/[^/]+/[^/]+/environments/[^/]+$"),  # Environment protection rules
+    re.compile(r"^repos/[^/]+/[^/]+/environments/[^/]+/deployment-branch-policies$"),
+    re.compile(r"^repos/[^/]+/[^/]+/environments/[^/]+/secrets$"),  # Secret names only
+    re.compile(r"^repos/[^/]+/[^/]+/environments/[^/]+/variables$"),  # Environment variables
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/pending_deployments$"),  # Runs awaiting a gate
+    re.compile(r"^repos/[^/]+/[^/]+/actions/runs/\d+/approvals$"),  # Gate review history
+    # Repository statistics
+    re.compile(r"^repos/[^/]+/[^/]+/stats/punch_card$"),  # Commits by weekday and hour
+    re.compile(r"^repos/[^/]+/[^/]+/stats/contributors$"),  # Per-author weekly activity
+    re.compile(r"^repos/[^/]+/[^/]+/stats/commit_activity$"),  # Weekly commits (last year)
+    re.compile(r"^repos/[^/]+/[^/]+/stats/code_frequency$"),  # Weekly additions/deletions
+    re.compile(r"^repos/[^/]+/[^/]+/stats/participation$"),  # Owner vs all commit counts
+    re.compile(r"^repos/[^/]+/[^/]+/traffic/(views|clones)$"),  # Views and clones (14 days)
+    re.compile(r"^repos/[^/]+/[^/]+/traffic/popular/(referrers|paths)$"),  # Top referrers and paths
+    # Repository documentation
+    re.compile(r"^repos/[^/]+/[^/]+/readme$"),  # README (raw or rendered via Accept)
+    re.compile(r"^repos/[^/]+/[^/]+/readme/.+$"),  # README for a directory
+    re.compile(r"^repos/[^/]+/[^/]+/community/profile$"),  # Community health files
+    re.compile(r"^repos/[^/]+/[^/]+/license$"),  # Detected license and contents
+    # Repository metadata
+    re.compile(r"^repos/[^/]+/[^/]+$"),  # Repo info (PATCH would change settings/visibility)
+    re.compile(r"^repos/[^/]+/[^/]+/topics$"),  # Repository topics
+    re.compile(r"^repos/[^/]+/[^/]+/autolinks$"),  # Autolink references
+    re.compile(r"^repos/[^/]+/[^/]+/autolinks/\d+$"),  # Specific autolink
+    # Community
+    re.compile(r"^repos/[^/]+/[^/]+/stargazers$"),  # Stargazers (starred_at via Accept)
+    re.compile(r"^repos/[^/]+/[^/]+/forks$"),  # List forks
+    re.compile(r"^repos/[^/]+/[^/]+/subscribers$"),  # Watchers
+    # Collaborators
+    re.compile(r"^repos/[^/]+/[^/]+/collaborators$"),  # List collaborators
+    re.compile(r"^repos/[^/]+/[^/]+/collaborators/[^/]+$"),  # Check if user is a collaborator
+    re.compile(r"^repos/[^/]+/[^/]+/collaborators/[^/]+/permission$"),  # User permission level
+    re.compile(r"^repos/[^/]+/[^/]+/invitations$"),  # Pending repository invitations
+    # Security alerts
+    re.compile(r"^repos/[^/]+/[^/]+/dependabot/alerts$"),  # Dependabot alerts
+    re.compile(r"^repos/[^/]+/[^/]+/dependabot/alerts/\d+$"),  # Specific Dependabot alert
+    re.compile(r"^repos/[^/]+/[^/]+/code-scanning/alerts$"),  # Code scanning alerts
+    re.compile(r"^repos/[^/]+/[^/]+/code-scanning/alerts/\d+$"),  # Specific code scanning alert
+    re.compile(r"^repos/[^/]+/[^/]+/code-scanning/alerts/\d+/instances$"),  # Alert instances
+    re.compile(r"^repos/[^/]+/[^/]+/security-advisories$"),  # Repository security advisories
+    re.compile(r"^repos/[^/]+/[^/]+/security-advisories/GHSA(-[a-z0-9]{4}){3}$"),  # Advisory
+    # Dependency graph
+    re.compile(r"^repos/[^/]+/[^/]+/dependency-graph/sbom$"),  # SPDX SBOM export
+    # Dependency review; basehead is {base}...{head} (refs may contain slashes)
+    re.compile(r"^repos/[^/]+/[^/]+/dependency-graph/compare/[^.].*\.\.\.[^.].*$"),
+    # Deployments
+    re.compile(r"^repos/[^/]+/[^/]+/deployments$"),  # List deployments
+    re.compile(r"^repos/[^/]+/[^/]+/deployments/\d+$"),  # Specific deployment
+    re.compile(r"^repos/[^/]+/[^/]+/deployments/\d+/statuses$"),  # Deployment statuses
+    re.compile(r"^repos/[^/]+/[^/]+/deployments/\d+/statuses/\d+$"),  # Specific status
+    # Activity
+    re.compile(r"^repos/[^/]+/[^/]+/issues/events$"),  # Repo-wide issue events
+    re.compile(r"^repos/[^/]+/[^/]+/issues/events/\d+$"),  # Specific issue event
+    re.compile(r"^repos/[^/]+/[^/]+/events$"),  # Repository activity feed
+]
+
 
 def validate_gh_api_path(path: str, method: str = "GET") -> tuple[bool, str]:
     """
@@ -143,14 +267,26 @@ def validate_gh_api_path(path: str, method: str = "GET") -> tuple[bool, str]:
     if method.upper() not in ("GET", "POST", "PATCH"):
         return False, f"HTTP method '{method}' not allowed for gh api"
 
-    # Strip leading slash if present
-    path = path.lstrip("/")
+    # Strip leading slash and query string (filters, pagination) if present
+    path = path.lstrip("/").split("?", 1)[0]
+
+    # Reject dot segments: patterns ending in .+ would otherwise let a path climb
+    # out of the repo it appears to target (and private mode would check the wrong repo)
+    if any(unquote(segment) in (".", "..") for segment in path.split("/")):
+        return False, f"API path '{path}' contains relative path segments"
 
     # Check against allowed patterns
     for pattern in GH_API_ALLOWED_PATHS:
         if pattern.match(path):
             return True, ""
 
+    # Check against read-only patterns
+    for pattern in GH_API_READONLY_PATHS:
+        if pattern.match(path):
+            if method.upper() != "GET":
+                return False, f"API path '{path}' is read-only (GET only)"
+            return True, ""
+
     return False, f"API path '{path}' not in allowlist"
 
 
@@ -166,6 +302,8 @@ GH_API_FLAGS_WITH_VALUES = frozenset(
         "--field",
         "-F",
         "--raw-field",
+        "-p",
+        "--preview",
         "-q",
         "--jq",
         "-t",
@@ -181,7 +319,6 @@ GH_API_FLAGS_WITH_VALUES = frozenset(
 # gh api flags that don't take a value (boolean flags)
 GH_API_FLAGS_NO_VALUE = frozenset(
     {
-        "-p",
         "--paginate",
         "--slurp",
         "-i",
@@ -191,74 +328,120 @@ GH_API_FLAGS_NO_VALUE = frozenset(
     }
 )
 
+# gh api flags that add a request body; gh switches the default method to POST
+GH_API_BODY_FLAGS = frozenset({"-f", "--field", "-F", "--raw-field", "--input"})
+
 
 def parse_gh_api_args(args: list[str]) -> tuple[str | None, str]:
     """
     Parse gh api command arguments to extract the API path and HTTP method.
 
-    The gh api command accepts various flags before the API path:
+    The gh api command accepts flags before and after the API path:
         gh api -X PATCH repos/owner/repo/pulls/123 -f base=main
         gh api --method POST -H "Accept: application/json" /repos/owner/repo/issues
+        gh api repos/owner/repo/issues/1/comments -f body=hi  (implicit POST)
 
-    This function properly skips flags and their values to find the actual API path.
+    Every argument is scanned, so a method flag after the path is honoured.
+    Like gh itself, the method defaults to POST when fields or --input are
+    given without an explicit method.
 
     Args:
         args: The argument list after 'api' (e.g., ["-X", "PATCH", "repos/..."])
 
     Returns:
         Tuple of (api_path, http_method).
-        api_path is None if no path could be found.
-        http_method defaults to "GET" if not specified.
+        api_path is None if no path, or more than one positional argument, was found.
+        http_method defaults to "GET" (or "POST" with body flags) if not specified.
     """
-    method = "GET"
-    api_path = None
+    method = None
+    sends_body = False
+    positionals: list[str] = []
     i = 0
 
     while i < len(args):
         arg = args[i]
 
-        # Check for HTTP method flag
-        if arg in ("-X", "--method"):
-            if i + 1 < len(args):
-                method = args[i + 1].upper()
-                i += 2
-                continue
-            else:
-                # Flag without value - skip it
-                i += 1
-                continue
+        # Long flag with inline value (e.g., --method=PATCH, --field=key=value)
+        if arg.startswith("--") and "=" in arg:
+            name, value = arg.split("=", 1)
+            if name == "--method":
+                method = value.upper()
+            elif name in GH_API_BODY_FLAGS:
+                sends_body = True
+            i += 1
+            continue
 
-        # Check for other flags that take values
+        # Flags that take a value as the next argument
         if arg in GH_API_FLAGS_WITH_VALUES:
-            # Skip flag and its value
+            if arg in ("-X", "--method") and i + 1 < len(args):
+                method = args[i + 1].upper()
+            elif arg in GH_API_BODY_FLAGS:
+                sends_body = True
             i += 2
             continue
 
-        # Check for flags that don't take values
         if arg in GH_API_FLAGS_NO_VALUE:
             i += 1
             continue
 
-        # Check for combined flag=value format (e.g., --method=PATCH, -f=key=value)
-        if "=" in arg and arg.startswith("-"):
-            # Handle method specially
-            if arg.startswith(("-X=", "--method=")):
-                method = arg.split("=", 1)[1].upper()
+        # Short flags with attached values or grouped booleans (-XPOST, -X=POST, -fkey=v, -iXPOST)
+        if arg.startswith("-") and not arg.startswith("--") and len(arg) > 1:
+            j = 1
+            while j < len(arg):
+                flag = "-" + arg[j]
+                if flag in GH_API_FLAGS_NO_VALUE:
+                    j += 1
+                    continue
+                if flag in GH_API_FLAGS_WITH_VALUES:
+                    value = arg[j + 1 :]
+                    if not value and i + 1 < len(args):
+                        value = args[i + 1]
+                        i += 1
+                    if flag == "-X":
+                        method = value.removeprefix("=").upper()
+                    elif flag in GH_API_BODY_FLAGS:
+                        sends_body = True
+                break
             i += 1
             continue
 
-        # Check for other flags we don't recognize (starts with -)
+        # Other flags we don't recognize (starts with -)
         if arg.startswith("-"):
             i += 1
             continue
 
-        # This is a positional argument - should be the API path
-        api_path = arg
-        break
+        positionals.append(arg)
+        i += 1
+
+    if method is None:
+        method = "POST" if sends_body else "GET"
 
+    # gh api takes exactly one endpoint; anything else means we misparsed a flag value
+    api_path = positionals[0] if len(positionals) == 1 else None
     return api_path, method
 
 
+def is_gh_write_command(args: list[str]) -> bool:
+    """
+    Check whether a gh invocation may mutate GitHub state.
+
+    gh api calls are judged by their effective HTTP method; other commands
+    count as writes unless listed in READONLY_GH_COMMANDS.
+
+    Args:
+        args: gh arguments (without the 'gh' prefix)
+
+    Returns:
+        True if the command is not known to be read-only
+    """
+    if not args or args[0] in ("--version", "--help", "version", "help"):
+        return False
+    if args[0] == "api":
+        _path, method = parse_gh_api_args(args[1:])
+        return method != "GET"
+    return " ".join(args[:2]) not in READONLY_GH_COMMANDS
+
+
 # =============================================================================
 # Repository Extraction for Private Mode Enforcement
 # =============================================================================
@@ -279,6 +462,7 @@ def extract_repo_from_gh_api_path(api_path: str) -> str | None:
         "repos/owner/repo/pulls" -> "owner/repo"
         "repos/owner/repo" -> "owner/repo"
         "/repos/owner/repo/issues/123" -> "owner/repo"
+        "repos/owner/repo?per_page=100" -> "owner/repo"
         "user" -> None
         "orgs/myorg/repos" -> None
 
@@ -288,7 +472,7 @@ def extract_repo_from_gh_api_path(api_path: str) -> str | None:
     Returns:
         "owner/repo" string or None if not a repo-scoped path
     """
-    path = api_path.lstrip("/")
+    path = api_path.lstrip("/").split("?", 1)[0]
 
     # Must start with "repos/"
     if not path.startswith("repos/"):
@@ -398,6 +582,7 @@ class GitHubResult:
     stdout: str
     stderr: str
     returncode: int
+    stdout_encoding: str = "utf-8"  # "base64" for binary output
 
     def to_dict(self) -> dict[str, Any]:
         """Convert to dictionary for API response."""
@@ -406,9 +591,34 @@ class GitHubResult:
             "stdout": self.stdout,
             "stderr": self.stderr,
             "returncode": self.returncode,
+            "stdout_encoding": self.stdout_encoding,
         }
 
 
+def decode_gh_output(data: bytes) -> tuple[str, str]:
+    """
+    Decode gh stdout for inclusion in a JSON response.
+
+    Text output is returned as-is. Binary output (log archives, artifact
+    zips, release assets) is base64-encoded so it survives the JSON round
+    trip; the container's gh wrapper decodes it back to raw bytes. Output
+    containing NUL bytes counts as binary even if it is valid UTF-8 (tar
+    archives, UTF-16 text), since the wrapper's shell text path drops NULs.
+
+    Args:
+        data: Raw stdout bytes from gh
+
+    Returns:
+        Tuple of (stdout, encoding) where encoding is "utf-8" or "base64"
+    """
+    if b"\x00" not in data:
+        try:
+            return data.decode("utf-8"), "utf-8"
+        except UnicodeDecodeError:
+            pass
+    return base64.b64encode(data).decode("ascii"), "base64"
+
+
 class GitHubClient:
     """Client for executing gh CLI commands with token management."""
 
@@ -636,40 +846,66 @@ class GitHubClient:
         logger.debug("Executing gh command", command_args=args, cwd=str(cwd) if cwd else None)
 
         try:
-            result = subprocess.run(
-                cmd,
-                capture_output=True,
-                text=True,
-                timeout=timeout,
-                cwd=cwd,
-                env=env,
-                check=False,
-            )
+            # Spool stdout to a temp file: gh api can return large binary payloads
+            # (zips, assets), which are size-checked before being read into memory
+            with tempfile.TemporaryFile() as stdout_file:
+                result = subprocess.run(
+                    cmd,
+                    stdout=stdout_file,
+                    stderr=subprocess.PIPE,
+                    timeout=timeout,
+                    cwd=cwd,
+                    env=env,
+                    check=False,
+                )
+                stdout_size = stdout_file.seek(0, os.SEEK_END)
+                if stdout_size > GH_MAX_OUTPUT_BYTES:
+                    logger.warning(
+                        "gh output exceeds size limit",
+                        command_args=args,
+                        size=stdout_size,
+                        limit=GH_MAX_OUTPUT_BYTES,
+                    )
+                    return GitHubResult(
+                        success=False,
+                        stdout="",
+                        stderr=(
+                            f"Output is {stdout_size} bytes, over the gateway limit of "
+                            f"{GH_MAX_OUTPUT_BYTES} bytes (GATEWAY_GH_MAX_OUTPUT_BYTES)"
+                        ),
+                        returncode=1,
+                    )
+                stdout_file.seek(0)
+                raw_stdout = stdout_file.read()
+
+            stdout, stdout_encoding = decode_gh_output(raw_stdout)
+            stderr = (result.stderr or b"").decode("utf-8", errors="replace")
 
             success = result.returncode == 0
             if not success:
                 # Check for GitHub rate limit errors
-                stderr_lower = (result.stderr or "").lower()
+                stderr_lower = stderr.lower()
                 if "rate limit" in stderr_lower or "api rate limit exceeded" in stderr_lower:
                     logger.error(
                         "GitHub rate limit exceeded",
                         command_args=args,
                         returncode=result.returncode,
-                        stderr=result.stderr[:500] if result.stderr else None,
+                        stderr=stderr[:500] if stderr else None,
                     )
                 else:
                     logger.warning(
                         "gh command failed",
                         command_args=args,
                         returncode=result.returncode,
-                        stderr=result.stderr[:500] if result.stderr else None,
+                        stderr=stderr[:500] if stderr else None,
                     )
 
             return GitHubResult(
                 success=success,
-                stdout=result.stdout,
-                stderr=result.stderr,
+                stdout=stdout,
+                stderr=stderr,
                 returncode=result.returncode,
+                stdout_encoding=stdout_encoding,
             )
 
         except subprocess.TimeoutExpired:
@@ -721,6 +957,32 @@ class GitHubClient:
             logger.error("Failed to parse PR info", stdout=result.stdout[:500])
             return None
 
+    def get_pr_updated_at(self, repo: str, pr_number: int, mode: str | None = None) -> str | None:
+        """
+        Get a PR's last-updated timestamp, used as its version for conflict checks.
+
+        Args:
+            repo: Repository in "owner/repo" format
+            pr_number: PR number
+            mode: Auth mode ("bot" or "user"), defaults to client mode
+
+        Returns:
+            ISO 8601 updatedAt string or None on error
+        """
+        result = self.execute(
+            ["pr", "view", str(pr_number), "--repo", repo, "--json", "updatedAt"],
+            mode=mode,
+        )
+
+        if not result.success:
+            return None
+
+        try:
+            return json.loads(result.stdout).get("updatedAt")
+        except json.JSONDecodeError:
+            logger.error("Failed to parse PR updatedAt", stdout=result.stdout[:500])
+            return None
+
     def list_prs_for_branch(
         self, repo: str, branch: str, state: str = "open"
     ) -> list[dict[str, Any]]:
@@ -13,6 +13,7 @@ Design decisions:
 - Separate limiters for different operations
 """
 
+import os
 import sys
 import threading
 from collections import defaultdict
@@ -31,6 +32,18 @@ from jib_logging import get_logger
 logger = get_logger("gateway-sidecar.rate-limiter")
 
 
+def _int_from_env(name: str, default: int) -> int:
+    """Read a positive integer setting from the environment, falling back to default."""
+    value = os.environ.get(name, "").strip()
+    if not value:
+        return default
+    try:
+        return max(1, int(value))
+    except ValueError:
+        logger.warning("Invalid integer setting, using default", setting=name, default=default)
+        return default
+
+
 @dataclass
 class RateLimitResult:
     """Result of a rate limit check."""
@@ -247,6 +260,28 @@ heartbeat_limiter = SlidingWindowRateLimiter(
     name="session_heartbeat",
 )
 
+# Repository writes: mutations per repo per hour (pushes, PR create/edit/comment/close)
+# Contains the blast radius if an agent loops on a failing fix
+repo_write_limiter = SlidingWindowRateLimiter(
+    max_requests=_int_from_env("GATEWAY_REPO_WRITES_PER_HOUR", 60),
+    window_seconds=3600,
+    name="repo_writes",
+)
+
+# Branch push cooldown: minimum interval between pushes to the same branch
+branch_push_limiter = SlidingWindowRateLimiter(
+    max_requests=1,
+    window_seconds=_int_from_env("GATEWAY_BRANCH_PUSH_INTERVAL_SECONDS", 15),
+    name="branch_push_cooldown",
+)
+
+# Write failures: failed or denied writes per session before it is quarantined
+write_failure_limiter = SlidingWindowRateLimiter(
+    max_requests=_int_from_env("GATEWAY_QUARANTINE_FAILURES", 10),
+    window_seconds=_int_from_env("GATEWAY_QUARANTINE_WINDOW_SECONDS", 300),
+    name="write_failures",
+)
+
 
 def check_registration_rate_limit(source_ip: str) -> RateLimitResult:
     """
@@ -289,6 +324,70 @@ def check_heartbeat_rate_limit(session_id: str) -> RateLimitResult:
     return heartbeat_limiter.is_allowed(session_id)
 
 
+def check_repo_write_rate_limit(repo: str) -> RateLimitResult:
+    """
+    Check and record a mutation against a repository's hourly write budget.
+
+    Args:
+        repo: Repository in owner/repo format
+
+    Returns:
+        RateLimitResult
+    """
+    return repo_write_limiter.is_allowed(repo.lower())
+
+
+def check_branch_push_cooldown(repo: str, branch: str) -> RateLimitResult:
+    """
+    Check whether a branch is still cooling down from its last push.
+
+    Does not record anything; call record_branch_push() once the push is
+    going ahead so that rejected pushes don't restart the cooldown.
+
+    Args:
+        repo: Repository in owner/repo format
+        branch: Branch name
+
+    Returns:
+        RateLimitResult
+    """
+    return branch_push_limiter.check_only(f"{repo.lower()}:{branch}")
+
+
+def record_branch_push(repo: str, branch: str) -> None:
+    """
+    Record a push to a branch, starting its cooldown.
+
+    Args:
+        repo: Repository in owner/repo format
+        branch: Branch name
+    """
+    branch_push_limiter.is_allowed(f"{repo.lower()}:{branch}")
+
+
+def record_write_failure(container_id: str) -> RateLimitResult:
+    """
+    Record a failed or denied write for a session.
+
+    Args:
+        container_id: Container ID of the session
+
+    Returns:
+        RateLimitResult; not allowed once the session exceeds the failure threshold
+    """
+    return write_failure_limiter.is_allowed(container_id)
+
+
+def reset_write_failures(container_id: str) -> None:
+    """
+    Clear recorded write failures for a session (e.g., when writes are restored).
+
+    Args:
+        container_id: Container ID of the session
+    """
+    write_failure_limiter.reset(container_id)
+
+
 def get_all_limiter_stats() -> dict:
     """
     Get statistics for all rate limiters.
@@ -300,4 +399,7 @@ def get_all_limiter_stats() -> dict:
         "registration": registration_limiter.get_stats(),
         "failed_lookup": failed_lookup_limiter.get_stats(),
         "heartbeat": heartbeat_limiter.get_stats(),
+        "repo_writes": repo_write_limiter.get_stats(),
+        "branch_push_cooldown": branch_push_limiter.get_stats(),
+        "write_failures": write_failure_limiter.get_stats(),
     }
@@ -86,6 +86,7 @@ class Session:
         created_at: Session creation timestamp
         last_seen: Last request timestamp (for heartbeat)
         expires_at: Session expiry timestamp
+        quarantined: Writes suspended after an error storm (until operator restore)
     """
 
     session_token: str | None  # Raw token, only in memory
@@ -96,6 +97,7 @@ class Session:
     created_at: datetime
     last_seen: datetime
     expires_at: datetime
+    quarantined: bool = False
 
     def is_expired(self) -> bool:
         """Check if session has expired."""
@@ -116,6 +118,7 @@ class Session:
             "created_at": self.created_at.isoformat(),
             "last_seen": self.last_seen.isoformat(),
             "expires_at": self.expires_at.isoformat(),
+            "quarantined": self.quarantined,
         }
 
     @classmethod
@@ -130,6 +133,7 @@ class Session:
             created_at=datetime.fromisoformat(data["created_at"]),
             last_seen=datetime.fromisoformat(data["last_seen"]),
             expires_at=datetime.fromisoformat(data["expires_at"]),
+            quarantined=data.get("quarantined", False),
         )
 
 
@@ -507,6 +511,33 @@ class SessionManager:
 
             return False
 
+    def set_quarantined(self, container_id: str, quarantined: bool) -> bool:
+        """
+        Suspend or restore writes for a container's session.
+
+        Args:
+            container_id: Docker container ID
+            quarantined: True to make the session read-only, False to restore writes
+
+        Returns:
+            True if the session was found, False otherwise
+        """
+        with self._lock:
+            for token_hash, session in self._sessions.items():
+                if session.container_id == container_id and not session.is_expired():
+                    session.quarantined = quarantined
+                    self._save_to_disk()
+
+                    logger.info(
+                        "Session quarantined" if quarantined else "Session writes restored",
+                        event_type="session_quarantined" if quarantined else "session_restored",
+                        session_token_hash=token_hash[:16],
+                        container_id=container_id,
+                    )
+                    return True
+
+            return False
+
     def prune_expired_sessions(self) -> int:
         """
         Remove all expired sessions.
@@ -555,6 +586,7 @@ class SessionManager:
                     "mode": session.mode,
                     "created_at": session.created_at.isoformat(),
                     "expires_at": session.expires_at.isoformat(),
+                    "quarantined": session.quarantined,
                 }
                 for session in self._sessions.values()
                 if not session.is_expired()
@@ -3,7 +3,7 @@
 # Run from inside jib container after gateway sidecar is deployed
 #
 # Usage:
-#   ./integration_test.sh [--output FILE] [--repo REPO_PATH]
+#   ./integration_test.sh [--output FILE] [--repo REPO_PATH] [--api-paths FILE]
 #
 # Output:
 #   Results written to ~/sharing/gateway-test-results.json (copyable to host)
@@ -22,6 +22,24 @@ SECRET_FILE="${HOME}/.config/jib/session-token"
 OUTPUT_FILE="${HOME}/sharing/gateway-test-results.json"
 REPO_PATH="${HOME}/repos/james-in-a-box"
 TEST_REPO="jwbron/james-in-a-box"
+# Read-only gh api paths exercised by test_gh_api_reads ({repo} = TEST_REPO)
+# Override with --api-paths FILE (one path per line, # comments allowed)
+API_PATHS_FILE=""
+DEFAULT_API_PATHS=(
+    "repos/{repo}"
+    "repos/{repo}/branches?per_page=5"
+    "repos/{repo}/tags?per_page=5"
+    "repos/{repo}/releases?per_page=5"
+    "repos/{repo}/readme"
+    "repos/{repo}/community/profile"
+    "repos/{repo}/topics"
+    "repos/{repo}/git/trees/main"
+    "repos/{repo}/actions/workflows"
+    "repos/{repo}/actions/runs?per_page=5"
+    "repos/{repo}/actions/artifacts?per_page=5"
+    "repos/{repo}/rulesets"
+    "repos/{repo}/environments"
+)
 
 # Parse arguments
 while [[ $# -gt 0 ]]; do
@@ -29,8 +47,11 @@ while [[ $# -gt 0 ]]; do
         --output) OUTPUT_FILE="$2"; shift 2 ;;
         --repo) REPO_PATH="$2"; shift 2 ;;
         --gateway) GATEWAY_URL="$2"; shift 2 ;;
+        --test-repo) TEST_REPO="$2"; shift 2 ;;
+        --api-paths) API_PATHS_FILE="$2"; shift 2 ;;
         -h|--help)
             echo "Usage: $0 [--output FILE] [--repo REPO_PATH] [--gateway URL]"
+            echo "          [--test-repo OWNER/REPO] [--api-paths FILE]"
             exit 0
             ;;
         *) echo "Unknown option: $1"; exit 1 ;;
@@ -401,6 +422,56 @@ test_gh_operations() {
     fi
 }
 
+#############################################
+# TEST CATEGORY: gh api Reads (smoke test of allowlisted endpoints)
+#############################################
+test_gh_api_reads() {
+    log_header "GH API READS (allowlisted endpoints)"
+
+    cd "$REPO_PATH" || return 1
+
+    local paths=()
+    if [[ -n "$API_PATHS_FILE" ]]; then
+        if [[ ! -f "$API_PATHS_FILE" ]]; then
+            log_fail "API paths file not found: $API_PATHS_FILE"
+            record_result "gh_api_paths_file" "fail" "File missing" "$API_PATHS_FILE"
+            return 1
+        fi
+        while IFS= read -r line; do
+            line="${line%%#*}"
+            line="${line//[[:space:]]/}"
+            [[ -n "$line" ]] && paths+=("$line")
+        done < "$API_PATHS_FILE"
+    else
+        paths=("${DEFAULT_API_PATHS[@]}")
+    fi
+
+    local path name
+    for path in "${paths[@]}"; do
+        path="${path//\{repo\}/$TEST_REPO}"
+        name="gh_api_read_$(echo "${path%%\?*}" | tr -c 'a-zA-Z0-9\n' '_')"
+
+        log_test "gh api $path"
+        RESP=$(gh api "$path" --silent 2>&1)
+        if [[ $? -eq 0 ]]; then
+            log_pass "Read allowed and succeeded"
+            record_result "$name" "pass" "Works" ""
+        elif echo "$RESP" | grep -qi "not in allowlist\|read-only\|not allowed"; then
+            log_fail "Read denied by gateway policy"
+            log_info "$RESP"
+            record_result "$name" "fail" "Denied by policy" "$RESP"
+        elif echo "$RESP" | grep -qi "not found\|HTTP 404\|HTTP 403"; then
+            # Allowed by the gateway, but the feature is unused or needs more access
+            log_skip "Allowed by gateway; not available for $TEST_REPO"
+            record_result "$name" "skip" "Not available for repo" "$RESP"
+        else
+            log_fail "Read failed"
+            log_info "$RESP"
+            record_result "$name" "fail" "Failed" "$RESP"
+        fi
+    done
+}
+
 #############################################
 # TEST CATEGORY: Blocked Operations
 #############################################
@@ -651,6 +722,7 @@ main() {
     test_authentication
     test_git_operations
     test_gh_operations
+    test_gh_api_reads
     test_blocked_operations
     test_rate_limiting
     test_fail_closed
@@ -23,6 +23,7 @@ import pytest
 # Import the test secrets and modules (loaded by conftest.py)
 TEST_LAUNCHER_SECRET = os.environ.get("JIB_LAUNCHER_SECRET", "test-launcher-secret-12345")
 import gateway
+import rate_limiter
 from policy import PolicyResult
 from session_manager import SessionValidationResult
 
@@ -52,6 +53,7 @@ def auth_headers():
     mock_session.mode = "public"
     mock_session.container_id = "test-container"
     mock_session.expires_at = None
+    mock_session.quarantined = False
 
     mock_result = SessionValidationResult(valid=True, session=mock_session)
 
@@ -64,6 +66,11 @@ def auth_headers():
         visibility="public",
     )
 
+    # Write limits are module-level state; start each test with a clean budget
+    rate_limiter.repo_write_limiter.reset_all()
+    rate_limiter.branch_push_limiter.reset_all()
+    rate_limiter.write_failure_limiter.reset_all()
+
     with (
         patch.object(gateway, "validate_session_for_request", return_value=mock_result),
         patch.object(gateway, "check_private_repo_access", return_value=mock_policy_result),
@@ -282,6 +289,356 @@ class TestGitPush:
             data = json.loads(response.data)
             assert data["success"] is True
 
+    def test_push_same_branch_within_cooldown_rate_limited(self, client, auth_headers):
+        """A second push to the same branch inside the cooldown is rejected with 429."""
+        with (
+            patch("subprocess.run") as mock_run,
+            patch.object(gateway, "get_policy_engine") as mock_policy,
+            patch.object(gateway, "get_token_for_repo") as mock_get_token,
+        ):
+            mock_run.return_value = MagicMock(
+                returncode=0,
+                stdout="https://github.com/owner/repo.git\n",
+                stderr="",
+            )
+            mock_engine = MagicMock()
+            mock_engine.check_branch_ownership.return_value = PolicyResult(
+                allowed=True,
+                reason="Branch is owned by jib",
+                details={"branch": "jib-feature"},
+            )
+            mock_policy.return_value = mock_engine
+            mock_get_token.return_value = ("test-token", "bot", "")
+
+            payload = json.dumps(
+                {
+                    "repo_path": "/home/jib/repos/test-repo",
+                    "remote": "origin",
+                    "refspec": "jib-feature",
+                }
+            )
+            first = client.post(
+                "/api/v1/git/push",
+                headers=auth_headers,
+                data=payload,
+                content_type="application/json",
+            )
+            second = client.post(
+                "/api/v1/git/push",
+                headers=auth_headers,
+                data=payload,
+                content_type="application/json",
+            )
+
+            assert first.status_code == 200
+            assert second.status_code == 429
+            data = json.loads(second.data)
+            assert "jib-feature" in data["message"]
+            assert data["data"]["retry_after_seconds"] >= 1
+
+    def test_push_token_failure_does_not_start_cooldown(self, client, auth_headers):
+        """A push that fails before git runs leaves the branch free to push again."""
+        with (
+            patch("subprocess.run") as mock_run,
+            patch.object(gateway, "get_policy_engine") as mock_policy,
+            patch.object(gateway, "get_token_for_repo") as mock_get_token,
+        ):
+            mock_run.return_value = MagicMock(
+                returncode=0,
+                stdout="https://github.com/owner/repo.git\n",
+                stderr="",
+            )
+            mock_engine = MagicMock()
+            mock_engine.check_branch_ownership.return_value = PolicyResult(
+                allowed=True,
+                reason="Branch is owned by jib",
+                details={"branch": "jib-feature"},
+            )
+            mock_policy.return_value = mock_engine
+            mock_get_token.side_effect = [
+                (None, "bot", "GitHub token not available"),
+                ("test-token", "bot", ""),
+            ]
+
+            payload = json.dumps(
+                {
+                    "repo_path": "/home/jib/repos/test-repo",
+                    "remote": "origin",
+                    "refspec": "jib-feature",
+                }
+            )
+            first = client.post(
+                "/api/v1/git/push",
+                headers=auth_headers,
+                data=payload,
+                content_type="application/json",
+            )
+            second = client.post(
+                "/api/v1/git/push",
+                headers=auth_headers,
+                data=payload,
+                content_type="application/json",
+            )
+
+            assert first.status_code == 503
+            assert second.status_code == 200
+
+
+class TestWriteQuarantine:
+    """Tests for quarantining sessions after repeated write failures."""
+
+    def test_quarantined_session_cannot_write(self, client, auth_headers):
+        """Writes from a quarantined session are rejected before any policy check."""
+        quarantined = MagicMock()
+        quarantined.mode = "public"
+        quarantined.container_id = "test-container"
+        quarantined.quarantined = True
+
+        with (
+            patch.object(
+                gateway,
+                "validate_session_for_request",
+                return_value=SessionValidationResult(valid=True, session=quarantined),
+            ),
+            patch.object(gateway, "get_github_client") as mock_gh,
+        ):
+            response = client.post(
+                "/api/v1/gh/pr/comment",
+                headers=auth_headers,
+                data=json.dumps({"repo": "test/repo", "pr_number": 1, "body": "Retry"}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 403
+            data = json.loads(response.data)
+            assert data["data"]["quarantined"] is True
+            mock_gh.return_value.execute.assert_not_called()
+
+    def test_error_storm_quarantines_session(self, client, auth_headers):
+        """A denied write past the failure threshold quarantines the session."""
+        storm = rate_limiter.RateLimitResult(allowed=False, remaining=0, retry_after_seconds=60)
+        with (
+            patch.object(gateway, "get_policy_engine") as mock_policy,
+            patch.object(gateway, "record_write_failure", return_value=storm),
+            patch.object(gateway, "get_session_manager") as mock_manager,
+        ):
+            mock_policy.return_value.check_pr_ownership.return_value = PolicyResult(
+                allowed=False,
+                reason="PR is not owned by jib",
+            )
+
+            response = client.post(
+                "/api/v1/gh/pr/close",
+                headers=auth_headers,
+                data=json.dumps({"repo": "test/repo", "pr_number": 1}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 403
+            mock_manager.return_value.set_quarantined.assert_called_once_with(
+                "test-container", True
+            )
+
+    def test_read_failures_do_not_count(self, client, auth_headers):
+        """Failed read-only gh commands are not treated as write failures."""
+        with (
+            patch.object(gateway, "get_github_client") as mock_gh,
+            patch.object(gateway, "record_write_failure") as mock_record,
+        ):
+            mock_gh.return_value.execute.return_value = MagicMock(
+                success=False,
+                stderr="not found",
+                to_dict=MagicMock(return_value={}),
+            )
+
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps({"args": ["pr", "view", "1", "--repo", "test/repo"]}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 500
+            mock_record.assert_not_called()
+
+    def test_quarantined_session_cannot_write_via_execute(self, client, auth_headers):
+        """Write commands through gh execute are blocked for quarantined sessions."""
+        quarantined = MagicMock()
+        quarantined.mode = "public"
+        quarantined.container_id = "test-container"
+        quarantined.quarantined = True
+
+        with (
+            patch.object(
+                gateway,
+                "validate_session_for_request",
+                return_value=SessionValidationResult(valid=True, session=quarantined),
+            ),
+            patch.object(gateway, "get_github_client") as mock_gh,
+        ):
+            for args in (
+                ["issue", "comment", "5", "--body", "Retry", "--repo", "test/repo"],
+                ["api", "repos/test/repo/issues/5/comments", "-X", "POST"],
+            ):
+                response = client.post(
+                    "/api/v1/gh/execute",
+                    headers=auth_headers,
+                    data=json.dumps({"args": args}),
+                    content_type="application/json",
+                )
+
+                assert response.status_code == 403
+                data = json.loads(response.data)
+                assert data["data"]["quarantined"] is True
+            mock_gh.return_value.execute.assert_not_called()
+
+    def test_quarantined_session_can_read_via_execute(self, client, auth_headers):
+        """Read-only gh commands still work for quarantined sessions."""
+        quarantined = MagicMock()
+        quarantined.mode = "public"
+        quarantined.container_id = "test-container"
+        quarantined.quarantined = True
+
+        with (
+            patch.object(
+                gateway,
+                "validate_session_for_request",
+                return_value=SessionValidationResult(valid=True, session=quarantined),
+            ),
+            patch.object(gateway, "get_github_client") as mock_gh,
+        ):
+            mock_gh.return_value.execute.return_value = MagicMock(
+                success=True,
+                to_dict=MagicMock(return_value={"success": True}),
+            )
+
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps({"args": ["pr", "view", "1", "--repo", "test/repo"]}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 200
+
+    def test_execute_write_failure_counts(self, client, auth_headers):
+        """A gh write command that fails on its own merits counts towards quarantine."""
+        ok = rate_limiter.RateLimitResult(allowed=True, remaining=5, retry_after_seconds=0)
+        with (
+            patch.object(gateway, "get_github_client") as mock_gh,
+            patch.object(gateway, "record_write_failure", return_value=ok) as mock_record,
+        ):
+            mock_gh.return_value.execute.return_value = MagicMock(
+                success=False,
+                stderr="GraphQL: Could not resolve to an issue or pull request",
+                to_dict=MagicMock(return_value={}),
+            )
+
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps(
+                    {"args": ["issue", "comment", "999", "--body", "x", "--repo", "test/repo"]}
+                ),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 500
+            mock_record.assert_called_once_with("test-container")
+
+    def test_transient_write_failure_does_not_count(self, client, auth_headers):
+        """GitHub outages and network errors are not held against the session."""
+        with (
+            patch.object(gateway, "get_policy_engine") as mock_policy,
+            patch.object(gateway, "get_github_client") as mock_gh,
+            patch.object(gateway, "record_write_failure") as mock_record,
+        ):
+            mock_policy.return_value.check_pr_ownership.return_value = PolicyResult(
+                allowed=True,
+                reason="PR is owned by jib",
+            )
+            mock_gh.return_value.execute.return_value = MagicMock(
+                success=False,
+                stderr="HTTP 502: Bad Gateway (https://api.github.com/graphql)",
+                to_dict=MagicMock(return_value={}),
+            )
+
+            response = client.post(
+                "/api/v1/gh/pr/close",
+                headers=auth_headers,
+                data=json.dumps({"repo": "test/repo", "pr_number": 1}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 500
+            mock_record.assert_not_called()
+
+    def test_token_unavailable_does_not_count(self, client, auth_headers):
+        """A 503 from the gateway's own token lookup is not a write failure."""
+        with (
+            patch("subprocess.run") as mock_run,
+            patch.object(gateway, "get_policy_engine") as mock_policy,
+            patch.object(gateway, "get_token_for_repo") as mock_get_token,
+            patch.object(gateway, "record_write_failure") as mock_record,
+        ):
+            mock_run.return_value = MagicMock(
+                returncode=0,
+                stdout="https://github.com/owner/repo.git\n",
+                stderr="",
+            )
+            mock_policy.return_value.check_branch_ownership.return_value = PolicyResult(
+                allowed=True,
+                reason="Branch is owned by jib",
+            )
+            mock_get_token.return_value = (None, "bot", "GitHub token not available")
+
+            response = client.post(
+                "/api/v1/git/push",
+                headers=auth_headers,
+                data=json.dumps(
+                    {
+                        "repo_path": "/home/jib/repos/test-repo",
+                        "remote": "origin",
+                        "refspec": "jib-feature",
+                    }
+                ),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 503
+            mock_record.assert_not_called()
+
+    def test_restore_writes(self, client, launcher_auth_headers):
+        """Operator can lift quarantine with the launcher secret."""
+        with patch.object(gateway, "get_session_manager") as mock_manager:
+            mock_manager.return_value.set_quarantined.return_value = True
+
+            response = client.post(
+                "/api/v1/sessions/restore-writes",
+                headers=launcher_auth_headers,
+                data=json.dumps({"container_id": "test-container"}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 200
+            mock_manager.return_value.set_quarantined.assert_called_once_with(
+                "test-container", False
+            )
+
+    def test_restore_writes_unknown_session(self, client, launcher_auth_headers):
+        """Restoring an unknown container returns 404."""
+        with patch.object(gateway, "get_session_manager") as mock_manager:
+            mock_manager.return_value.set_quarantined.return_value = False
+
+            response = client.post(
+                "/api/v1/sessions/restore-writes",
+                headers=launcher_auth_headers,
+                data=json.dumps({"container_id": "missing"}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 404
+
 
 class TestGhPrCreate:
     """Tests for /api/v1/gh/pr/create endpoint."""
@@ -354,6 +711,26 @@ class TestGhPrCreate:
             assert data["success"] is True
             assert "pull/42" in data["data"]["stdout"]
 
+    def test_pr_create_repo_write_budget_exhausted(self, client, auth_headers):
+        """PR create is rejected with 429 once the repo's hourly write budget is spent."""
+        denied = rate_limiter.RateLimitResult(allowed=False, remaining=0, retry_after_seconds=120)
+        with (
+            patch.object(gateway, "check_repo_write_rate_limit", return_value=denied),
+            patch.object(gateway, "get_github_client") as mock_gh,
+        ):
+            response = client.post(
+                "/api/v1/gh/pr/create",
+                headers=auth_headers,
+                data=json.dumps({"repo": "test/repo", "title": "Add feature", "head": "branch"}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 429
+            data = json.loads(response.data)
+            assert "test/repo" in data["message"]
+            assert data["data"]["retry_after_seconds"] == 120
+            mock_gh.return_value.execute.assert_not_called()
+
 
 class TestGhPrComment:
     """Tests for /api/v1/gh/pr/comment endpoint."""
@@ -463,6 +840,89 @@ class TestGhPrEdit:
 
             assert response.status_code == 403
 
+    def _edit_with_version(
+        self, client, auth_headers, current_updated_at, expected="2024-01-01T00:00:00Z"
+    ):
+        """Post a versioned PR edit with ownership allowed and updatedAt mocked."""
+        with (
+            patch.object(gateway, "get_policy_engine") as mock_policy,
+            patch.object(gateway, "get_github_client") as mock_gh,
+        ):
+            mock_engine = MagicMock()
+            mock_engine.check_pr_ownership.return_value = PolicyResult(
+                allowed=True,
+                reason="PR is owned by jib",
+                details={"author": "jib"},
+            )
+            mock_policy.return_value = mock_engine
+
+            mock_gh.return_value.get_pr_updated_at.return_value = current_updated_at
+            mock_result = MagicMock()
+            mock_result.success = True
+            mock_result.stdout = "PR edited"
+            mock_result.stderr = ""
+            mock_gh.return_value.execute.return_value = mock_result
+
+            response = client.post(
+                "/api/v1/gh/pr/edit",
+                headers=auth_headers,
+                data=json.dumps(
+                    {
+                        "repo": "test/repo",
+                        "pr_number": 123,
+                        "body": "New body",
+                        "expected_updated_at": expected,
+                    }
+                ),
+                content_type="application/json",
+            )
+            return response, mock_gh.return_value
+
+    def test_pr_edit_allowed_when_version_matches(self, client, auth_headers):
+        """PR edit proceeds when expected_updated_at matches."""
+        response, github = self._edit_with_version(client, auth_headers, "2024-01-01T00:00:00Z")
+
+        assert response.status_code == 200
+        github.execute.assert_called_once()
+
+    def test_pr_edit_conflict_when_version_changed(self, client, auth_headers):
+        """PR edit rejected with 409 when the PR changed since it was read."""
+        response, github = self._edit_with_version(client, auth_headers, "2024-01-02T12:00:00Z")
+
+        assert response.status_code == 409
+        data = json.loads(response.data)
+        assert data["data"]["current_updated_at"] == "2024-01-02T12:00:00Z"
+        assert data["data"]["expected_updated_at"] == "2024-01-01T00:00:00Z"
+        github.execute.assert_not_called()
+
+    def test_pr_edit_fails_closed_when_version_unknown(self, client, auth_headers):
+        """PR edit rejected when the current version cannot be fetched."""
+        response, github = self._edit_with_version(client, auth_headers, None)
+
+        assert response.status_code == 500
+        github.execute.assert_not_called()
+
+    def test_pr_edit_version_compares_instants(self, client, auth_headers):
+        """Equivalent timestamps in different formats are the same version."""
+        response, github = self._edit_with_version(
+            client, auth_headers, "2024-01-01T00:00:00Z", expected="2024-01-01T00:00:00+00:00"
+        )
+
+        assert response.status_code == 200
+        github.execute.assert_called_once()
+
+    def test_pr_edit_invalid_expected_version(self, client, auth_headers):
+        """An unparseable expected_updated_at is rejected with 400."""
+        response, github = self._edit_with_version(
+            client, auth_headers, "2024-01-01T00:00:00Z", expected="yesterday"
+        )
+
+        assert response.status_code == 400
+        data = json.loads(response.data)
+        assert "expected_updated_at" in data["message"]
+        github.get_pr_updated_at.assert_not_called()
+        github.execute.assert_not_called()
+
 
 class TestGhPrClose:
     """Tests for /api/v1/gh/pr/close endpoint."""
@@ -644,6 +1104,115 @@ class TestGhExecute:
             assert executed_args[1] == "owner/repo"
             assert executed_args[2] == "pr"
 
+    def test_execute_api_method_after_path_blocked(self, client, auth_headers):
+        """A method flag placed after the path is honoured for read-only paths."""
+        with patch.object(gateway, "get_github_client") as mock_gh:
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps({"args": ["api", "repos/owner/repo/forks", "-X", "POST"]}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 403
+            data = json.loads(response.data)
+            assert "read-only" in data["message"]
+            mock_gh.return_value.execute.assert_not_called()
+
+    def test_execute_api_implicit_post_blocked(self, client, auth_headers):
+        """Field flags without -X make gh POST, so read-only paths reject them."""
+        with patch.object(gateway, "get_github_client") as mock_gh:
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps(
+                    {
+                        "args": [
+                            "api",
+                            "repos/owner/repo/actions/runs/5/pending_deployments",
+                            "-f",
+                            "state=approved",
+                        ]
+                    }
+                ),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 403
+            data = json.loads(response.data)
+            assert "read-only" in data["message"]
+            mock_gh.return_value.execute.assert_not_called()
+
+    def test_execute_api_put_after_path_blocked(self, client, auth_headers):
+        """PUT via --method after the path is rejected."""
+        with patch.object(gateway, "get_github_client") as mock_gh:
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps(
+                    {
+                        "args": [
+                            "api",
+                            "repos/owner/repo/branches/main/protection",
+                            "--method",
+                            "PUT",
+                            "--input",
+                            "p.json",
+                        ]
+                    }
+                ),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 403
+            data = json.loads(response.data)
+            assert "method" in data["message"].lower()
+            mock_gh.return_value.execute.assert_not_called()
+
+    def test_execute_write_repo_write_budget_exhausted(self, client, auth_headers):
+        """Write commands through execute are charged against the repo write budget."""
+        denied = rate_limiter.RateLimitResult(allowed=False, remaining=0, retry_after_seconds=90)
+        with (
+            patch.object(gateway, "check_repo_write_rate_limit", return_value=denied),
+            patch.object(gateway, "get_github_client") as mock_gh,
+            patch.object(gateway, "get_auth_mode", return_value="bot"),
+        ):
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps(
+                    {"args": ["issue", "comment", "5", "--body", "hi"], "repo": "owner/repo"}
+                ),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 429
+            data = json.loads(response.data)
+            assert "owner/repo" in data["message"]
+            mock_gh.return_value.execute.assert_not_called()
+
+    def test_execute_read_not_charged_to_write_budget(self, client, auth_headers):
+        """Read-only commands through execute don't consume the repo write budget."""
+        with (
+            patch.object(gateway, "check_repo_write_rate_limit") as mock_budget,
+            patch.object(gateway, "get_github_client") as mock_gh,
+            patch.object(gateway, "get_auth_mode", return_value="bot"),
+        ):
+            mock_result = MagicMock()
+            mock_result.success = True
+            mock_result.to_dict.return_value = {"success": True, "stdout": "", "stderr": ""}
+            mock_gh.return_value.execute.return_value = mock_result
+
+            response = client.post(
+                "/api/v1/gh/execute",
+                headers=auth_headers,
+                data=json.dumps({"args": ["pr", "view", "5"], "repo": "owner/repo"}),
+                content_type="application/json",
+            )
+
+            assert response.status_code == 200
+            mock_budget.assert_not_called()
+
 
 class TestGitFetch:
     """Tests for /api/v1/git/fetch endpoint."""
@@ -927,6 +1496,7 @@ class TestRepoExtraction:
         assert extract_repo_from_gh_api_path("repos/owner/repo/pulls") == "owner/repo"
         assert extract_repo_from_gh_api_path("repos/owner/repo") == "owner/repo"
         assert extract_repo_from_gh_api_path("/repos/owner/repo/issues/123") == "owner/repo"
+        assert extract_repo_from_gh_api_path("repos/owner/repo?per_page=100") == "owner/repo"
 
     def test_extract_repo_from_gh_api_path_non_repo(self):
         """Non-repo paths return None."""
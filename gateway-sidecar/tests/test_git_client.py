@@ -313,7 +313,7 @@ class TestGitHubClientExecuteEnvironment:
             patch.object(client, "get_token_for_mode", return_value="test-token"),
             patch("github_client.subprocess.run") as mock_run,
         ):
-            mock_run.return_value = MagicMock(returncode=0, stdout="success", stderr="")
+            mock_run.return_value = MagicMock(returncode=0, stdout=b"success", stderr=b"")
 
             client.execute(["pr", "view", "123"])
 
@@ -340,5 +340,127 @@ class TestGitHubClientExecuteEnvironment:
             assert found_ssh_protocol, "Missing URL rewrite for ssh://git@github.com/ format"
 
 
+class TestGitHubClientExecuteOutput:
+    """Tests for how GitHubClient.execute() returns gh output.
+
+    Binary payloads (log archives, artifact zips, release assets) cannot be
+    carried in a JSON string, so they are base64-encoded.
+    """
+
+    def _execute(self, stdout: bytes, stderr: bytes = b"", returncode: int = 0):
+        from unittest.mock import MagicMock, patch
+
+        from github_client import GitHubClient
+
+        client = GitHubClient()
+
+        with (
+            patch.object(client, "get_token_for_mode", return_value="test-token"),
+            patch("github_client.subprocess.run") as mock_run,
+        ):
+            # stdout is spooled to the file handle passed by execute()
+            def run(cmd, **kwargs):
+                kwargs["stdout"].write(stdout)
+                return MagicMock(returncode=returncode, stdout=None, stderr=stderr)
+
+            mock_run.side_effect = run
+            return client.execute(["api", "repos/owner/repo/actions/artifacts/11/zip"])
+
+    def test_text_output_returned_as_utf8(self):
+        """UTF-8 output is returned unchanged."""
+        result = self._execute('{"name": "café"}'.encode())
+
+        assert result.success is True
+        assert result.stdout == '{"name": "café"}'
+        assert result.stdout_encoding == "utf-8"
+
+    def test_binary_output_base64_encoded(self):
+        """Non-UTF-8 output is base64-encoded."""
+        import base64
+
+        payload = b"PK\x03\x04\xff\xfe\x00binary"
+        result = self._execute(payload)
+
+        assert result.success is True
+        assert result.stdout_encoding == "base64"
+        assert base64.b64decode(result.stdout) == payload
+
+    def test_nul_bearing_utf8_output_base64_encoded(self):
+        """Output with NUL bytes is base64-encoded even when it is valid UTF-8."""
+        import base64
+
+        payload = "hi".encode("utf-16-le") + b"readme.txt\x00\x00\x00"
+        payload.decode("utf-8")  # valid UTF-8, so only the NUL check catches it
+        result = self._execute(payload)
+
+        assert result.stdout_encoding == "base64"
+        assert base64.b64decode(result.stdout) == payload
+
+    def test_stderr_decoded_leniently(self):
+        """Undecodable stderr bytes are replaced rather than raising."""
+        result = self._execute(b"", stderr=b"bad \xff byte", returncode=1)
+
+        assert result.success is False
+        assert result.stderr == "bad \ufffd byte"
+
+    def test_to_dict_includes_encoding(self):
+        """API response includes the stdout encoding."""
+        result = self._execute(b"\xff\xd8\xff")
+
+        assert result.to_dict()["stdout_encoding"] == "base64"
+
+    def test_output_over_size_limit_refused(self):
+        """Output larger than GH_MAX_OUTPUT_BYTES is refused with a clear error."""
+        from unittest.mock import patch
+
+        with patch("github_client.GH_MAX_OUTPUT_BYTES", 8):
+            result = self._execute(b"PK\x03\x04" + b"\x00" * 16)
+
+        assert result.success is False
+        assert result.stdout == ""
+        assert "GATEWAY_GH_MAX_OUTPUT_BYTES" in result.stderr
+
+    def test_output_at_size_limit_returned(self):
+        """Output exactly at the limit is returned."""
+        from unittest.mock import patch
+
+        with patch("github_client.GH_MAX_OUTPUT_BYTES", 8):
+            result = self._execute(b"12345678")
+
+        assert result.success is True
+        assert result.stdout == "12345678"
+
+
+class TestGitHubClientPrVersion:
+    """Tests for GitHubClient.get_pr_updated_at() (PR edit conflict checks)."""
+
+    def test_returns_updated_at(self):
+        """Returns the PR's updatedAt field."""
+        from unittest.mock import patch
+
+        from github_client import GitHubClient, GitHubResult
+
+        client = GitHubClient()
+        result = GitHubResult(
+            success=True, stdout='{"updatedAt": "2024-01-01T00:00:00Z"}', stderr="", returncode=0
+        )
+
+        with patch.object(client, "execute", return_value=result) as mock_execute:
+            assert client.get_pr_updated_at("owner/repo", 123) == "2024-01-01T00:00:00Z"
+            assert "updatedAt" in mock_execute.call_args.args[0]
+
+    def test_returns_none_on_failure(self):
+        """Returns None when the PR cannot be read."""
+        from unittest.mock import patch
+
+        from github_client import GitHubClient, GitHubResult
+
+        client = GitHubClient()
+        result = GitHubResult(success=False, stdout="", stderr="not found", returncode=1)
+
+        with patch.object(client, "execute", return_value=result):
+            assert client.get_pr_updated_at("owner/repo", 123) is None
+
+
 if __name__ == "__main__":
     pytest.main([__file__, "-v"])
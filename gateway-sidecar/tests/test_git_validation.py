@@ -324,75 +324,1007 @@ class TestGhApiPathValidation:
         assert valid is True
         assert error == ""
 
+    def test_release_create_allowed(self):
+        """Creating a release is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/releases", method="POST"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_release_edit_allowed(self):
+        """Editing a release is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/releases/12345", method="PATCH"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_release_generate_notes_allowed(self):
+        """Generating release notes between tags is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/releases/generate-notes", method="POST"
+        )
+        assert valid is True
+        assert error == ""
+
     def test_repo_info_allowed(self):
         """Repo info endpoint is allowed."""
         valid, error = github_client.validate_gh_api_path("repos/owner/repo")
         assert valid is True
         assert error == ""
 
-    def test_branches_allowed(self):
-        """Branches endpoint is allowed."""
-        valid, error = github_client.validate_gh_api_path("repos/owner/repo/branches")
+    def test_repo_settings_update_blocked(self):
+        """PATCH on the repository itself (settings, visibility) is blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo", method="PATCH")
+        assert valid is False
+        assert "read-only" in error
+
+    def test_repo_visibility_change_via_fields_blocked(self):
+        """gh api repos/o/r -X PATCH -f visibility=public parses as PATCH and is blocked."""
+        path, method = github_client.parse_gh_api_args(
+            ["repos/owner/repo", "-X", "PATCH", "-f", "visibility=public"]
+        )
+        valid, error = github_client.validate_gh_api_path(path, method)
+        assert valid is False
+        assert "read-only" in error
+
+    def test_branches_allowed(self):
+        """Branches endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/branches")
+        assert valid is True
+        assert error == ""
+
+    def test_branches_with_query_allowed(self):
+        """Branches endpoint with filter and pagination query is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/branches?protected=true&per_page=100&page=2"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_query_string_does_not_extend_path(self):
+        """Query string is ignored when matching, so it cannot smuggle a path."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/hooks?x=/pulls")
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_contents_file_allowed(self):
+        """File contents endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/contents/README.md")
+        assert valid is True
+        assert error == ""
+
+    def test_contents_directory_allowed(self):
+        """Directory listing via the contents endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/contents/src/lib?ref=main"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_user_info_allowed(self):
+        """User info endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("user")
+        assert valid is True
+        assert error == ""
+
+    def test_leading_slash_stripped(self):
+        """Leading slash is stripped before validation."""
+        valid, error = github_client.validate_gh_api_path("/repos/owner/repo/pulls")
+        assert valid is True
+        assert error == ""
+
+    def test_unknown_path_blocked(self):
+        """Unknown API paths are blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/unknown")
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_admin_paths_blocked(self):
+        """Admin/dangerous paths are blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/hooks")
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_delete_method_blocked(self):
+        """DELETE method is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/pulls/123", method="DELETE"
+        )
+        assert valid is False
+        assert "method" in error.lower()
+
+    def test_get_method_allowed(self):
+        """GET method is allowed."""
+        valid, _error = github_client.validate_gh_api_path("repos/owner/repo/pulls", method="GET")
+        assert valid is True
+
+    def test_post_method_allowed(self):
+        """POST method is allowed."""
+        valid, _error = github_client.validate_gh_api_path(
+            "repos/owner/repo/pulls/123/comments", method="POST"
+        )
+        assert valid is True
+
+    def test_patch_method_allowed(self):
+        """PATCH method is allowed."""
+        valid, _error = github_client.validate_gh_api_path(
+            "repos/owner/repo/pulls/123", method="PATCH"
+        )
+        assert valid is True
+
+    def test_pr_number_must_be_numeric(self):
+        """PR number must be numeric."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/pulls/abc")
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_git_commit_object_allowed(self):
+        """Git commit object (with signature verification) is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/git/commits/abc123def456"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_git_tag_object_allowed(self):
+        """Annotated tag object (with signature verification) is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/git/tags/abc123def456")
+        assert valid is True
+        assert error == ""
+
+    def test_readonly_path_post_blocked(self):
+        """POST to a read-only path is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/git/commits/abc123def456", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_readonly_path_patch_blocked(self):
+        """PATCH to a read-only path is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/git/tags/abc123def456", method="PATCH"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_codeowners_errors_allowed(self):
+        """CODEOWNERS errors endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/codeowners/errors?ref=main"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_codeowners_errors_post_blocked(self):
+        """POST to CODEOWNERS errors endpoint is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/codeowners/errors", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_branch_protection_allowed(self):
+        """Branch protection endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/branches/main/protection"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_branch_protection_subresource_allowed(self):
+        """Branch protection sub-resources are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/branches/main/protection/required_status_checks"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_branch_protection_patch_blocked(self):
+        """Updating branch protection is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/branches/main/protection/required_pull_request_reviews",
+            method="PATCH",
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_rulesets_list_allowed(self):
+        """Rulesets list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/rulesets?includes_parents=true"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_ruleset_by_id_allowed(self):
+        """Specific ruleset endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/rulesets/42")
+        assert valid is True
+        assert error == ""
+
+    def test_rule_suites_allowed(self):
+        """Rule suite evaluation results are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/rulesets/rule-suites?ref=refs/heads/main"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_branch_rules_allowed(self):
+        """Rules for a branch are allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/rules/branches/main")
+        assert valid is True
+        assert error == ""
+
+    def test_ruleset_create_blocked(self):
+        """Creating a ruleset is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/rulesets", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_tags_list_allowed(self):
+        """Tags list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/tags?per_page=100")
+        assert valid is True
+        assert error == ""
+
+    def test_tags_list_post_blocked(self):
+        """POST to tags list endpoint is blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/tags", method="POST")
+        assert valid is False
+        assert "read-only" in error
+
+    def test_release_assets_list_allowed(self):
+        """Release assets list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/releases/12345/assets")
+        assert valid is True
+        assert error == ""
+
+    def test_release_asset_allowed(self):
+        """Specific release asset endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/releases/assets/67890")
+        assert valid is True
+        assert error == ""
+
+    def test_release_asset_patch_blocked(self):
+        """Editing a release asset is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/releases/assets/67890", method="PATCH"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_workflows_list_allowed(self):
+        """Workflows list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/actions/workflows")
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_by_file_name_allowed(self):
+        """Workflow by file name endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/workflows/test.yml"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_post_blocked(self):
+        """POST to a workflow endpoint is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/workflows/161335", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_workflow_runs_list_allowed(self):
+        """Workflow runs list endpoint with filters is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs?branch=main&status=failure&created=>=2024-01-01"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_runs_for_workflow_allowed(self):
+        """Runs for a specific workflow are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/workflows/ci.yml/runs?event=push"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_run_allowed(self):
+        """Specific workflow run endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/actions/runs/30433642")
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_run_jobs_allowed(self):
+        """Jobs for a workflow run are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/jobs?filter=latest"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_run_attempt_jobs_allowed(self):
+        """Jobs for a run attempt are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/attempts/2/jobs"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_job_allowed(self):
+        """Specific workflow job endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/actions/jobs/399444496")
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_run_rerun_blocked(self):
+        """Re-running a workflow run is not allowlisted."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/rerun"
+        )
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_workflow_job_logs_allowed(self):
+        """Workflow job logs endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/jobs/399444496/logs"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_run_logs_allowed(self):
+        """Workflow run log archive endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/logs"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_run_logs_post_blocked(self):
+        """POST to workflow run logs is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/logs", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_pages_config_allowed(self):
+        """Pages configuration endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/pages")
+        assert valid is True
+        assert error == ""
+
+    def test_pages_latest_build_allowed(self):
+        """Latest Pages build endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/pages/builds/latest")
+        assert valid is True
+        assert error == ""
+
+    def test_pages_build_by_id_allowed(self):
+        """Specific Pages build endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/pages/builds/5472601")
+        assert valid is True
+        assert error == ""
+
+    def test_pages_build_trigger_blocked(self):
+        """Triggering a Pages build is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/pages/builds", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_environments_list_allowed(self):
+        """Environments list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/environments")
+        assert valid is True
+        assert error == ""
+
+    def test_environment_allowed(self):
+        """Specific environment endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/environments/production"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_environment_branch_policies_allowed(self):
+        """Environment branch policies are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/environments/production/deployment-branch-policies"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_environment_secrets_list_allowed(self):
+        """Environment secret names are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/environments/production/secrets"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_environment_variables_list_allowed(self):
+        """Environment variables are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/environments/production/variables"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_environment_secret_value_blocked(self):
+        """Individual environment secrets are not allowlisted."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/environments/production/secrets/TOKEN"
+        )
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_environment_variable_create_blocked(self):
+        """Creating an environment variable is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/environments/production/variables", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_artifacts_list_allowed(self):
+        """Artifacts list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/artifacts?name=coverage"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_run_artifacts_allowed(self):
+        """Artifacts for a workflow run are allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/artifacts"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_artifact_download_allowed(self):
+        """Artifact zip download endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/artifacts/11/zip"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_stats_punch_card_allowed(self):
+        """Punch card statistics endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/stats/punch_card")
+        assert valid is True
+        assert error == ""
+
+    def test_actions_caches_list_allowed(self):
+        """Actions caches list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/caches?sort=size_in_bytes"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_actions_cache_usage_allowed(self):
+        """Actions cache usage endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/actions/cache/usage")
+        assert valid is True
+        assert error == ""
+
+    def test_workflow_timing_allowed(self):
+        """Workflow billable timing endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/workflows/ci.yml/timing"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_run_timing_allowed(self):
+        """Workflow run timing endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/30433642/timing"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_actions_cache_delete_blocked(self):
+        """Deleting Actions caches is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/caches", method="DELETE"
+        )
+        assert valid is False
+        assert "method" in error.lower()
+
+    def test_git_tree_allowed(self):
+        """Git tree endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/git/trees/main")
+        assert valid is True
+        assert error == ""
+
+    def test_git_tree_recursive_allowed(self):
+        """Recursive git tree endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/git/trees/abc123def456?recursive=1"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_git_tree_post_blocked(self):
+        """Creating tree objects is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/git/trees/main", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_readme_allowed(self):
+        """README endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/readme?ref=main")
+        assert valid is True
+        assert error == ""
+
+    def test_directory_readme_allowed(self):
+        """Directory README endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/readme/docs")
+        assert valid is True
+        assert error == ""
+
+    def test_community_profile_allowed(self):
+        """Community profile endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/community/profile")
+        assert valid is True
+        assert error == ""
+
+    def test_readme_post_blocked(self):
+        """POST to README endpoint is blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/readme", method="POST")
+        assert valid is False
+        assert "read-only" in error
+
+    def test_readme_dot_segments_blocked(self):
+        """Dot segments can't climb out of the readme path into another repo."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/pub/repo/readme/../../../other/private/contents/x"
+        )
+        assert valid is False
+        assert "relative path segments" in error
+
+    def test_encoded_dot_segments_blocked(self):
+        """Percent-encoded dot segments are rejected too."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/pub/repo/contents/%2e%2e/%2E%2E/other/private/contents/x"
+        )
+        assert valid is False
+        assert "relative path segments" in error
+
+    def test_dots_within_segment_allowed(self):
+        """Dots inside a segment (file names) are not dot segments."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/contents/a/..b/.env")
+        assert valid is True
+        assert error == ""
+
+    def test_git_blob_allowed(self):
+        """Git blob endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/git/blobs/abc123def456")
+        assert valid is True
+        assert error == ""
+
+    def test_git_blob_post_blocked(self):
+        """Creating blob objects is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/git/blobs/abc123def456", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_topics_allowed(self):
+        """Repository topics endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/topics")
+        assert valid is True
+        assert error == ""
+
+    def test_topics_replace_blocked(self):
+        """Replacing repository topics is blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/topics", method="PUT")
+        assert valid is False
+        assert "method" in error.lower()
+
+    def test_traffic_views_allowed(self):
+        """Traffic views endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/traffic/views?per=day")
         assert valid is True
         assert error == ""
 
-    def test_user_info_allowed(self):
-        """User info endpoint is allowed."""
-        valid, error = github_client.validate_gh_api_path("user")
+    def test_traffic_clones_allowed(self):
+        """Traffic clones endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/traffic/clones")
         assert valid is True
         assert error == ""
 
-    def test_leading_slash_stripped(self):
-        """Leading slash is stripped before validation."""
-        valid, error = github_client.validate_gh_api_path("/repos/owner/repo/pulls")
+    def test_traffic_popular_referrers_allowed(self):
+        """Top referrers endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/traffic/popular/referrers"
+        )
         assert valid is True
         assert error == ""
 
-    def test_unknown_path_blocked(self):
-        """Unknown API paths are blocked."""
-        valid, error = github_client.validate_gh_api_path("repos/owner/repo/unknown")
+    def test_traffic_popular_paths_allowed(self):
+        """Top paths endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/traffic/popular/paths")
+        assert valid is True
+        assert error == ""
+
+    def test_stats_contributors_allowed(self):
+        """Contributor statistics endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/stats/contributors")
+        assert valid is True
+        assert error == ""
+
+    def test_stats_commit_activity_allowed(self):
+        """Commit activity statistics endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/stats/commit_activity")
+        assert valid is True
+        assert error == ""
+
+    def test_stats_code_frequency_allowed(self):
+        """Code frequency statistics endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/stats/code_frequency")
+        assert valid is True
+        assert error == ""
+
+    def test_stats_participation_allowed(self):
+        """Participation statistics endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/stats/participation")
+        assert valid is True
+        assert error == ""
+
+    def test_stats_unknown_blocked(self):
+        """Unknown statistics endpoints are not allowlisted."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/stats/unknown")
         assert valid is False
         assert "not in allowlist" in error
 
-    def test_admin_paths_blocked(self):
-        """Admin/dangerous paths are blocked."""
-        valid, error = github_client.validate_gh_api_path("repos/owner/repo/hooks")
+    def test_stargazers_allowed(self):
+        """Stargazers endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/stargazers?per_page=100"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_forks_list_allowed(self):
+        """Forks list endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/forks?sort=newest")
+        assert valid is True
+        assert error == ""
+
+    def test_subscribers_allowed(self):
+        """Watchers endpoint is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/subscribers")
+        assert valid is True
+        assert error == ""
+
+    def test_fork_create_blocked(self):
+        """Creating a fork via the API is blocked."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/forks", method="POST")
         assert valid is False
-        assert "not in allowlist" in error
+        assert "read-only" in error
 
-    def test_delete_method_blocked(self):
-        """DELETE method is blocked."""
+    def test_collaborators_list_allowed(self):
+        """Listing collaborators (with permissions) is allowed."""
         valid, error = github_client.validate_gh_api_path(
-            "repos/owner/repo/pulls/123", method="DELETE"
+            "repos/owner/repo/collaborators?affiliation=direct"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_collaborator_permission_allowed(self):
+        """Checking a user's permission level is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/collaborators/octocat/permission"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_collaborator_add_blocked(self):
+        """Adding a collaborator (PUT) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/collaborators/octocat", method="PUT"
         )
         assert valid is False
         assert "method" in error.lower()
 
-    def test_get_method_allowed(self):
-        """GET method is allowed."""
-        valid, _error = github_client.validate_gh_api_path("repos/owner/repo/pulls", method="GET")
+    def test_collaborator_remove_blocked(self):
+        """Removing a collaborator (DELETE) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/collaborators/octocat", method="DELETE"
+        )
+        assert valid is False
+        assert "method" in error.lower()
+
+    def test_repo_invitations_list_allowed(self):
+        """Listing pending repository invitations is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/invitations")
         assert valid is True
+        assert error == ""
 
-    def test_post_method_allowed(self):
-        """POST method is allowed."""
-        valid, _error = github_client.validate_gh_api_path(
-            "repos/owner/repo/pulls/123/comments", method="POST"
+    def test_repo_invitation_cancel_blocked(self):
+        """Cancelling an invitation is not allowlisted."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/invitations/42", method="DELETE"
+        )
+        assert valid is False
+        assert "method" in error.lower()
+
+    def test_dependabot_alerts_list_allowed(self):
+        """Listing Dependabot alerts with filters is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/dependabot/alerts?severity=high&ecosystem=pip&state=open"
         )
         assert valid is True
+        assert error == ""
 
-    def test_patch_method_allowed(self):
-        """PATCH method is allowed."""
-        valid, _error = github_client.validate_gh_api_path(
-            "repos/owner/repo/pulls/123", method="PATCH"
+    def test_dependabot_alert_get_allowed(self):
+        """Getting a specific Dependabot alert is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/dependabot/alerts/7")
+        assert valid is True
+        assert error == ""
+
+    def test_dependabot_alert_dismiss_blocked(self):
+        """Dismissing a Dependabot alert (PATCH) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/dependabot/alerts/7", method="PATCH"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_org_dependabot_alerts_not_allowlisted(self):
+        """Org-level Dependabot alerts are not allowlisted."""
+        valid, error = github_client.validate_gh_api_path("orgs/myorg/dependabot/alerts")
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_code_scanning_alerts_list_allowed(self):
+        """Listing code scanning alerts is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/code-scanning/alerts?tool_name=CodeQL&severity=high"
         )
         assert valid is True
+        assert error == ""
 
-    def test_pr_number_must_be_numeric(self):
-        """PR number must be numeric."""
-        valid, error = github_client.validate_gh_api_path("repos/owner/repo/pulls/abc")
+    def test_code_scanning_alert_get_allowed(self):
+        """Getting a specific code scanning alert is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/code-scanning/alerts/12"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_code_scanning_alert_instances_allowed(self):
+        """Listing code scanning alert instances is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/code-scanning/alerts/12/instances"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_code_scanning_alert_dismiss_blocked(self):
+        """Dismissing a code scanning alert (PATCH) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/code-scanning/alerts/12", method="PATCH"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_security_advisories_list_allowed(self):
+        """Listing repository security advisories is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/security-advisories?state=draft"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_security_advisory_get_allowed(self):
+        """Getting an advisory by GHSA ID is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/security-advisories/GHSA-abcd-1234-wxyz"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_security_advisory_create_blocked(self):
+        """Creating a draft advisory (POST) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/security-advisories", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_security_advisory_malformed_id_blocked(self):
+        """Malformed advisory IDs don't match."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/security-advisories/GHSA-abcd/cve"
+        )
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_dependency_graph_sbom_allowed(self):
+        """Exporting the SPDX SBOM is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/dependency-graph/sbom")
+        assert valid is True
+        assert error == ""
+
+    def test_dependency_graph_compare_allowed(self):
+        """Dependency review between two refs is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/dependency-graph/compare/main...jib/feature"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_dependency_graph_compare_requires_basehead(self):
+        """Compare requires a {base}...{head} range, not an arbitrary sub-path."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/dependency-graph/compare/main"
+        )
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_dependency_graph_compare_dot_segments_blocked(self):
+        """Compare can't be used to climb into another repository."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/pub/repo/dependency-graph/compare/a...b/../../../../../other/private/contents/x"
+        )
+        assert valid is False
+        assert "relative path segments" in error
+
+    def test_dependency_graph_snapshot_blocked(self):
+        """Submitting a dependency snapshot is not allowlisted."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/dependency-graph/snapshots", method="POST"
+        )
+        assert valid is False
+        assert "not in allowlist" in error
+
+    def test_deployments_list_allowed(self):
+        """Listing deployments is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/deployments?environment=production"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_deployment_get_allowed(self):
+        """Getting a specific deployment is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/deployments/101")
+        assert valid is True
+        assert error == ""
+
+    def test_deployment_statuses_allowed(self):
+        """Listing deployment statuses is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/deployments/101/statuses"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_deployment_create_blocked(self):
+        """Creating a deployment (POST) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/deployments", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_deployment_status_create_blocked(self):
+        """Creating a deployment status (POST) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/deployments/101/statuses", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_pending_deployments_allowed(self):
+        """Listing deployments waiting on environment gates is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/555/pending_deployments"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_run_approvals_allowed(self):
+        """Reading a run's environment approval history is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/555/approvals"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_pending_deployment_review_blocked(self):
+        """Approving or rejecting a pending deployment (POST) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/actions/runs/555/pending_deployments", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_autolinks_list_allowed(self):
+        """Listing autolink references is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/autolinks")
+        assert valid is True
+        assert error == ""
+
+    def test_autolink_get_allowed(self):
+        """Getting a specific autolink is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/autolinks/3")
+        assert valid is True
+        assert error == ""
+
+    def test_autolink_create_blocked(self):
+        """Creating an autolink (POST) is blocked."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/autolinks", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_repo_issue_events_allowed(self):
+        """Listing issue events across a repository is allowed."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/issues/events?per_page=100"
+        )
+        assert valid is True
+        assert error == ""
+
+    def test_issue_event_get_allowed(self):
+        """Getting a single issue event is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/issues/events/987654")
+        assert valid is True
+        assert error == ""
+
+    def test_repo_issue_events_write_blocked(self):
+        """Issue events are GET-only."""
+        valid, error = github_client.validate_gh_api_path(
+            "repos/owner/repo/issues/events", method="POST"
+        )
+        assert valid is False
+        assert "read-only" in error
+
+    def test_repo_events_allowed(self):
+        """Listing a repository's events feed is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/events")
+        assert valid is True
+        assert error == ""
+
+    def test_org_events_not_allowlisted(self):
+        """Org-wide events feeds are not allowlisted."""
+        valid, error = github_client.validate_gh_api_path("orgs/myorg/events")
         assert valid is False
         assert "not in allowlist" in error
 
+    def test_repo_license_allowed(self):
+        """Getting the detected license is allowed."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/license")
+        assert valid is True
+        assert error == ""
+
+    def test_repo_license_write_blocked(self):
+        """The license endpoint is GET-only."""
+        valid, error = github_client.validate_gh_api_path("repos/owner/repo/license", method="POST")
+        assert valid is False
+        assert "read-only" in error
+
 
 class TestParseGhApiArgs:
     """Tests for parse_gh_api_args function."""
@@ -529,6 +1461,107 @@ class TestParseGhApiArgs:
         assert path == "repos/owner/repo/pulls"
         assert method == "GET"
 
+    def test_method_flag_after_path(self):
+        """Method flags after the path are honoured."""
+        path, method = github_client.parse_gh_api_args(["repos/o/r/forks", "-X", "POST"])
+        assert path == "repos/o/r/forks"
+        assert method == "POST"
+
+    def test_long_method_flag_after_path(self):
+        """Long method flags after the path are honoured."""
+        _path, method = github_client.parse_gh_api_args(
+            ["repos/o/r/branches/main/protection", "--method", "PUT", "--input", "p.json"]
+        )
+        assert method == "PUT"
+
+    def test_fields_imply_post(self):
+        """Field flags without an explicit method mean POST, as in gh."""
+        path, method = github_client.parse_gh_api_args(
+            ["repos/o/r/actions/runs/5/pending_deployments", "-f", "state=approved"]
+        )
+        assert path == "repos/o/r/actions/runs/5/pending_deployments"
+        assert method == "POST"
+
+    def test_typed_field_implies_post(self):
+        """Typed field (-F) and inline --raw-field also imply POST."""
+        _path, method = github_client.parse_gh_api_args(["repos/o/r/topics", "-F", "x=1"])
+        assert method == "POST"
+        _path, method = github_client.parse_gh_api_args(["repos/o/r/topics", "--raw-field=x=1"])
+        assert method == "POST"
+
+    def test_input_implies_post(self):
+        """--input without an explicit method means POST."""
+        _path, method = github_client.parse_gh_api_args(["repos/o/r/forks", "--input", "-"])
+        assert method == "POST"
+
+    def test_explicit_get_with_fields_stays_get(self):
+        """Explicit GET with fields sends them as query parameters."""
+        _path, method = github_client.parse_gh_api_args(
+            ["-X", "GET", "repos/o/r/dependabot/alerts", "-f", "state=open"]
+        )
+        assert method == "GET"
+
+    def test_attached_short_method(self):
+        """Short method flag with attached value (-XPOST) is parsed."""
+        _path, method = github_client.parse_gh_api_args(["repos/o/r/forks", "-XPOST"])
+        assert method == "POST"
+
+    def test_grouped_short_flags(self):
+        """Grouped short flags (-iX POST) are parsed."""
+        path, method = github_client.parse_gh_api_args(["-iX", "POST", "repos/o/r/forks"])
+        assert path == "repos/o/r/forks"
+        assert method == "POST"
+
+    def test_attached_short_field_implies_post(self):
+        """Short field flag with attached value (-fkey=v) implies POST."""
+        _path, method = github_client.parse_gh_api_args(["repos/o/r/forks", "-fname=x"])
+        assert method == "POST"
+
+    def test_preview_flag_takes_value(self):
+        """-p/--preview consumes its value instead of treating it as the path."""
+        path, method = github_client.parse_gh_api_args(["-p", "corsair", "repos/o/r/issues"])
+        assert path == "repos/o/r/issues"
+        assert method == "GET"
+
+    def test_multiple_positionals_return_none(self):
+        """More than one positional argument is ambiguous and yields no path."""
+        path, _method = github_client.parse_gh_api_args(
+            ["repos/o/r/issues", "repos/o/r/forks", "-X", "POST"]
+        )
+        assert path is None
+
+
+class TestIsGhWriteCommand:
+    """Tests for is_gh_write_command function."""
+
+    def test_read_only_commands_are_not_writes(self):
+        """Commands in READONLY_GH_COMMANDS are reads."""
+        assert not github_client.is_gh_write_command(["pr", "view", "5"])
+        assert not github_client.is_gh_write_command(["run", "list"])
+        assert not github_client.is_gh_write_command(["search", "issues", "bug"])
+
+    def test_version_and_help_are_not_writes(self):
+        """Version and help invocations are reads."""
+        assert not github_client.is_gh_write_command(["--version"])
+        assert not github_client.is_gh_write_command(["help"])
+
+    def test_other_commands_are_writes(self):
+        """Commands outside the read-only set are treated as writes."""
+        assert github_client.is_gh_write_command(["issue", "comment", "5", "--body", "x"])
+        assert github_client.is_gh_write_command(["release", "create", "v1"])
+
+    def test_api_get_is_not_write(self):
+        """gh api without a method or fields is a GET."""
+        assert not github_client.is_gh_write_command(["api", "repos/o/r/pulls"])
+
+    def test_api_explicit_method_is_write(self):
+        """gh api with a non-GET method is a write."""
+        assert github_client.is_gh_write_command(["api", "repos/o/r/issues", "-X", "POST"])
+
+    def test_api_field_flag_is_write(self):
+        """gh api with a field flag implies POST and is a write."""
+        assert github_client.is_gh_write_command(["api", "repos/o/r/issues", "-f", "title=x"])
+
 
 class TestSharedHelperFunctions:
     """Tests for shared credential helper functions."""
@@ -13,13 +13,21 @@ import pytest
 from rate_limiter import (
     RateLimitResult,
     SlidingWindowRateLimiter,
+    branch_push_limiter,
+    check_branch_push_cooldown,
     check_heartbeat_rate_limit,
     check_registration_rate_limit,
+    check_repo_write_rate_limit,
     failed_lookup_limiter,
     get_all_limiter_stats,
     heartbeat_limiter,
+    record_branch_push,
     record_failed_lookup,
+    record_write_failure,
     registration_limiter,
+    repo_write_limiter,
+    reset_write_failures,
+    write_failure_limiter,
 )
 
 
@@ -247,6 +255,27 @@ class TestPreConfiguredLimiters:
         assert stats["max_requests"] == 100
         assert stats["window_seconds"] == 3600
 
+    def test_repo_write_limiter_exists(self):
+        """Test repo write limiter is configured."""
+        stats = repo_write_limiter.get_stats()
+        assert stats["name"] == "repo_writes"
+        assert stats["max_requests"] == 60
+        assert stats["window_seconds"] == 3600
+
+    def test_branch_push_limiter_exists(self):
+        """Test branch push cooldown limiter is configured."""
+        stats = branch_push_limiter.get_stats()
+        assert stats["name"] == "branch_push_cooldown"
+        assert stats["max_requests"] == 1
+        assert stats["window_seconds"] == 15
+
+    def test_write_failure_limiter_exists(self):
+        """Test write failure limiter is configured."""
+        stats = write_failure_limiter.get_stats()
+        assert stats["name"] == "write_failures"
+        assert stats["max_requests"] == 10
+        assert stats["window_seconds"] == 300
+
 
 class TestConvenienceFunctions:
     """Tests for module-level convenience functions."""
@@ -278,3 +307,40 @@ class TestConvenienceFunctions:
         assert "registration" in stats
         assert "failed_lookup" in stats
         assert "heartbeat" in stats
+        assert "repo_writes" in stats
+        assert "branch_push_cooldown" in stats
+        assert "write_failures" in stats
+
+    def test_record_write_failure(self):
+        """Test write failures trip the threshold and can be reset."""
+        reset_write_failures("failing-container")
+        for _ in range(10):
+            assert record_write_failure("failing-container").allowed is True
+        assert record_write_failure("failing-container").allowed is False
+
+        reset_write_failures("failing-container")
+        assert record_write_failure("failing-container").allowed is True
+        reset_write_failures("failing-container")
+
+    def test_check_repo_write_rate_limit(self):
+        """Test repo write budget is keyed case-insensitively by repo."""
+        repo_write_limiter.reset("owner/write-repo")
+        result = check_repo_write_rate_limit("Owner/Write-Repo")
+        assert result.allowed is True
+        assert repo_write_limiter.check_only("owner/write-repo").remaining == 59
+        repo_write_limiter.reset("owner/write-repo")
+
+    def test_branch_push_cooldown(self):
+        """Test cooldown starts only once a push is recorded."""
+        branch_push_limiter.reset("owner/push-repo:feature")
+        assert check_branch_push_cooldown("owner/push-repo", "feature").allowed is True
+        # Checking alone doesn't start the cooldown
+        assert check_branch_push_cooldown("owner/push-repo", "feature").allowed is True
+
+        record_branch_push("owner/push-repo", "feature")
+        result = check_branch_push_cooldown("owner/push-repo", "feature")
+        assert result.allowed is False
+        assert result.retry_after_seconds >= 1
+        # Other branches are unaffected
+        assert check_branch_push_cooldown("owner/push-repo", "other").allowed is True
+        branch_push_limiter.reset("owner/push-repo:feature")
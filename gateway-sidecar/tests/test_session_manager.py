@@ -342,6 +342,26 @@ class TestSessionManager:
         assert count == 3
         assert manager.list_sessions() == []
 
+    def test_set_quarantined(self, manager):
+        """Test quarantining and restoring a session by container ID."""
+        token, session = manager.register_session(
+            container_id="test-container",
+            container_ip="172.18.0.5",
+            mode="private",
+        )
+        assert session.quarantined is False
+
+        assert manager.set_quarantined("test-container", True) is True
+        assert manager.get_session(token).quarantined is True
+        assert manager.list_sessions()[0]["quarantined"] is True
+
+        assert manager.set_quarantined("test-container", False) is True
+        assert manager.get_session(token).quarantined is False
+
+    def test_set_quarantined_nonexistent(self, manager):
+        """Test quarantining a non-existent container."""
+        assert manager.set_quarantined("nonexistent", True) is False
+
 
 class TestSessionManagerPersistence:
     """Tests for session persistence."""
@@ -378,6 +398,21 @@ class TestSessionManagerPersistence:
         assert session1.mode == "private"
         assert session2.mode == "public"
 
+    def test_quarantine_persists(self, tmp_path):
+        """Test quarantine survives a gateway restart."""
+        persist_path = tmp_path / "sessions.json"
+
+        manager1 = SessionManager(persistence_file=persist_path)
+        manager1.register_session(
+            container_id="container-1",
+            container_ip="172.18.0.5",
+            mode="private",
+        )
+        manager1.set_quarantined("container-1", True)
+
+        manager2 = SessionManager(persistence_file=persist_path)
+        assert manager2.get_session_by_container("container-1").quarantined is True
+
     def test_atomic_persistence(self, tmp_path):
         """Test that persistence is atomic (write to temp then rename)."""
         persist_path = tmp_path / "sessions.json"